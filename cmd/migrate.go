@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Dias221467/MicroServices/internal/migrate"
+	"github.com/Dias221467/MicroServices/migrations"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up | down N | status | goto V")
+
+func newMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	m, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	m.Logger = logger
+	return m, nil
+}
+
+// runMigrate executes the `migrate` subcommand with the arguments following it.
+func runMigrate(ctx context.Context, db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	m, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("down: invalid step count %q", args[1])
+		}
+		return m.Down(ctx, n)
+	case "goto":
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+		v, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("goto: invalid version %q", args[1])
+		}
+		return m.Goto(ctx, uint(v))
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("current version: %d (latest %d)\n", status.Current, status.Latest)
+		for _, a := range status.Applied {
+			fmt.Printf("  applied  %06d_%s at %s\n", a.Version, a.Name, a.AppliedAt.Format("2006-01-02 15:04:05"))
+		}
+		for _, p := range status.Pending {
+			fmt.Printf("  pending  %06d_%s\n", p.Version, p.Name)
+		}
+		return nil
+	default:
+		return errMigrateUsage
+	}
+}
@@ -0,0 +1,53 @@
+// Package audit identifies who makes each change, so that the repositories
+// can attribute the history entries they write.
+package audit
+
+import (
+	"context"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// ActorHeader names the actor over HTTP. The service doesn't
+	// authenticate it: deployments should have a trusted proxy set it.
+	ActorHeader = "X-Actor"
+	// ActorMetadata names the actor in gRPC metadata.
+	ActorMetadata = "x-actor"
+
+	// Anonymous is the actor of requests that don't name one.
+	Anonymous = "anonymous"
+	// System is the actor of the service's own background work.
+	System = "system"
+
+	maxActorLen = 256
+)
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx attributing changes to actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the actor carried by ctx, or Anonymous.
+func Actor(ctx context.Context) string {
+	if actor, _ := ctx.Value(actorKey{}).(string); actor != "" {
+		return actor
+	}
+	return Anonymous
+}
+
+// actorOrAnonymous returns actor when it is short, valid UTF-8 without
+// control characters, and Anonymous otherwise.
+func actorOrAnonymous(actor string) string {
+	if actor == "" || len(actor) > maxActorLen || !utf8.ValidString(actor) {
+		return Anonymous
+	}
+	for _, r := range actor {
+		if unicode.IsControl(r) {
+			return Anonymous
+		}
+	}
+	return actor
+}
@@ -0,0 +1,12 @@
+package audit
+
+import "net/http"
+
+// Middleware attributes the changes made by each request to the actor in
+// its X-Actor header.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithActor(r.Context(), actorOrAnonymous(r.Header.Get(ActorHeader)))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,505 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Storage backends selectable with the storage setting.
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// Outbox sinks selectable with the outbox.sink setting.
+const (
+	OutboxSinkNone      = "none"
+	OutboxSinkFile      = "file"
+	OutboxSinkKafkaREST = "kafka_rest"
+)
+
+// Config is the service configuration as read from configs/config.yaml.
+type Config struct {
+	Storage  string         `yaml:"storage"`
+	Database DatabaseConfig `yaml:"database"`
+	HTTP     HTTPConfig     `yaml:"http"`
+	GRPC     GRPCConfig     `yaml:"grpc"`
+	Timeouts TimeoutsConfig `yaml:"timeouts"`
+	Shutdown ShutdownConfig `yaml:"shutdown"`
+	Health   HealthConfig   `yaml:"health"`
+	Trash    TrashConfig    `yaml:"trash"`
+	Outbox   OutboxConfig   `yaml:"outbox"`
+	Webhooks WebhooksConfig `yaml:"webhooks"`
+	Changes  ChangesConfig  `yaml:"changes"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+}
+
+type DatabaseConfig struct {
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	AutoMigrate     bool          `yaml:"auto_migrate"`
+}
+
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+}
+
+type GRPCConfig struct {
+	Addr              string        `yaml:"addr"`
+	ConnectionTimeout time.Duration `yaml:"connection_timeout"`
+}
+
+// TimeoutsConfig bounds each book operation end to end; zero disables the limit.
+type TimeoutsConfig struct {
+	Create time.Duration `yaml:"create"`
+	Get    time.Duration `yaml:"get"`
+	List   time.Duration `yaml:"list"`
+	Search time.Duration `yaml:"search"`
+	Update time.Duration `yaml:"update"`
+	Delete time.Duration `yaml:"delete"`
+}
+
+// ShutdownConfig controls how the service drains on SIGTERM.
+type ShutdownConfig struct {
+	// DrainDelay is how long to keep serving after reporting not-ready, so
+	// that load balancers stop routing before listeners close.
+	DrainDelay time.Duration `yaml:"drain_delay"`
+	// Timeout bounds draining in-flight requests and the cleanup after it.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// HealthConfig controls the readiness checks behind /readyz and the gRPC
+// health service.
+type HealthConfig struct {
+	// CheckTimeout bounds each individual dependency check.
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+	// Interval is how often the gRPC health status is refreshed.
+	Interval time.Duration `yaml:"interval"`
+}
+
+// TrashConfig controls how long deleted books can be restored.
+type TrashConfig struct {
+	// Retention is how long a book stays in the trash before it is purged;
+	// zero keeps deleted books until they are restored.
+	Retention time.Duration `yaml:"retention"`
+	// PurgeInterval is how often books past the retention are purged.
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// OutboxConfig controls the relay that publishes book events from the
+// outbox to webhooks and the sink.
+type OutboxConfig struct {
+	// Sink is none, file or kafka_rest. With none, events only go to
+	// webhooks.
+	Sink string `yaml:"sink"`
+	// File is where the file sink appends events as JSON lines.
+	File string `yaml:"file"`
+	// BrokerURL is the base URL of the Kafka REST Proxy for kafka_rest.
+	BrokerURL string `yaml:"broker_url"`
+	// Topic is the Kafka topic events are produced to.
+	Topic string `yaml:"topic"`
+	// Interval is how often the outbox is polled for due events.
+	Interval time.Duration `yaml:"interval"`
+	// BatchSize is how many events are claimed at a time.
+	BatchSize int `yaml:"batch_size"`
+	// Lease is how long a claimed batch has to be published before its
+	// events are offered again.
+	Lease time.Duration `yaml:"lease"`
+	// RetryBackoff is the delay after a first failed attempt, doubling up
+	// to MaxRetryBackoff.
+	RetryBackoff    time.Duration `yaml:"retry_backoff"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff"`
+	// Retention is how long published events are kept; zero keeps them.
+	Retention time.Duration `yaml:"retention"`
+}
+
+// WebhooksConfig controls the delivery of book events to webhooks.
+type WebhooksConfig struct {
+	// Interval is how often due deliveries are looked for.
+	Interval time.Duration `yaml:"interval"`
+	// BatchSize is how many deliveries are claimed, and sent concurrently,
+	// at a time.
+	BatchSize int `yaml:"batch_size"`
+	// Lease is how long a claimed batch has to be delivered before its
+	// deliveries are offered again.
+	Lease time.Duration `yaml:"lease"`
+	// Timeout bounds each delivery request.
+	Timeout time.Duration `yaml:"timeout"`
+	// RetryBackoff is the delay after a first failed attempt, doubling up
+	// to MaxRetryBackoff.
+	RetryBackoff    time.Duration `yaml:"retry_backoff"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff"`
+	// MaxAttempts is how many attempts a delivery gets before it fails.
+	MaxAttempts int `yaml:"max_attempts"`
+	// DisableAfter is how many failed attempts in a row disable a webhook;
+	// zero never disables.
+	DisableAfter int `yaml:"disable_after"`
+	// AllowPrivateTargets lets deliveries reach loopback, link-local and
+	// private addresses. Leave it off unless every caller of POST /webhooks
+	// is trusted with the service's network.
+	AllowPrivateTargets bool `yaml:"allow_private_targets"`
+}
+
+// ChangesConfig controls the GET /books/changes event stream.
+type ChangesConfig struct {
+	// PollInterval is how often open streams look for new changes.
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// Heartbeat is how long a stream may stay silent before a comment is
+	// sent to keep the connection open.
+	Heartbeat time.Duration `yaml:"heartbeat"`
+}
+
+// LogConfig controls the structured logger.
+type LogConfig struct {
+	// Level is one of debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Format is json or text.
+	Format string `yaml:"format"`
+}
+
+// TracingConfig selects where OpenTelemetry spans are exported.
+type TracingConfig struct {
+	// Exporter is none, otlp or stdout.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure disables TLS towards the collector.
+	Insecure bool `yaml:"insecure"`
+	// File is where the stdout exporter writes; empty means standard output.
+	File string `yaml:"file"`
+	// SampleRatio is the fraction of new traces recorded, from 0 to 1.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Default returns the configuration used for any value not set by the file or environment.
+func Default() *Config {
+	return &Config{
+		Storage: StoragePostgres,
+		Database: DatabaseConfig{
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		HTTP: HTTPConfig{
+			Addr:              ":8080",
+			ReadTimeout:       10 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      10 * time.Second,
+			IdleTimeout:       60 * time.Second,
+		},
+		GRPC: GRPCConfig{
+			Addr:              ":50051",
+			ConnectionTimeout: 10 * time.Second,
+		},
+		Timeouts: TimeoutsConfig{
+			Create: 5 * time.Second,
+			Get:    2 * time.Second,
+			List:   5 * time.Second,
+			Search: 5 * time.Second,
+			Update: 5 * time.Second,
+			Delete: 5 * time.Second,
+		},
+		Shutdown: ShutdownConfig{
+			Timeout: 20 * time.Second,
+		},
+		Health: HealthConfig{
+			CheckTimeout: 2 * time.Second,
+			Interval:     5 * time.Second,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Outbox: OutboxConfig{
+			Sink:            OutboxSinkNone,
+			Topic:           "books.events",
+			Interval:        time.Second,
+			BatchSize:       100,
+			Lease:           30 * time.Second,
+			RetryBackoff:    time.Second,
+			MaxRetryBackoff: 5 * time.Minute,
+			Retention:       7 * 24 * time.Hour,
+		},
+		Webhooks: WebhooksConfig{
+			Interval:        time.Second,
+			BatchSize:       50,
+			Lease:           time.Minute,
+			Timeout:         10 * time.Second,
+			RetryBackoff:    10 * time.Second,
+			MaxRetryBackoff: time.Hour,
+			MaxAttempts:     10,
+			DisableAfter:    20,
+		},
+		Changes: ChangesConfig{
+			PollInterval: time.Second,
+			Heartbeat:    15 * time.Second,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4317",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Load reads the YAML file at path on top of the defaults, applies environment
+// overrides, then the given overrides (typically command-line flags), and
+// validates the result. An empty path skips the file.
+func Load(path string, overrides ...func(*Config)) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config: %w", err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing config %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	for _, override := range overrides {
+		override(cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// envOverrides lists the environment variables that take precedence over the file.
+func (c *Config) envOverrides() map[string]any {
+	return map[string]any{
+		"STORAGE":                        &c.Storage,
+		"DATABASE_DSN":                   &c.Database.DSN,
+		"DATABASE_MAX_OPEN_CONNS":        &c.Database.MaxOpenConns,
+		"DATABASE_MAX_IDLE_CONNS":        &c.Database.MaxIdleConns,
+		"DATABASE_CONN_MAX_LIFETIME":     &c.Database.ConnMaxLifetime,
+		"DATABASE_CONN_MAX_IDLE_TIME":    &c.Database.ConnMaxIdleTime,
+		"DATABASE_AUTO_MIGRATE":          &c.Database.AutoMigrate,
+		"HTTP_ADDR":                      &c.HTTP.Addr,
+		"HTTP_READ_TIMEOUT":              &c.HTTP.ReadTimeout,
+		"HTTP_READ_HEADER_TIMEOUT":       &c.HTTP.ReadHeaderTimeout,
+		"HTTP_WRITE_TIMEOUT":             &c.HTTP.WriteTimeout,
+		"HTTP_IDLE_TIMEOUT":              &c.HTTP.IdleTimeout,
+		"GRPC_ADDR":                      &c.GRPC.Addr,
+		"GRPC_CONNECTION_TIMEOUT":        &c.GRPC.ConnectionTimeout,
+		"TIMEOUT_CREATE":                 &c.Timeouts.Create,
+		"TIMEOUT_GET":                    &c.Timeouts.Get,
+		"TIMEOUT_LIST":                   &c.Timeouts.List,
+		"TIMEOUT_SEARCH":                 &c.Timeouts.Search,
+		"TIMEOUT_UPDATE":                 &c.Timeouts.Update,
+		"TIMEOUT_DELETE":                 &c.Timeouts.Delete,
+		"SHUTDOWN_DRAIN_DELAY":           &c.Shutdown.DrainDelay,
+		"SHUTDOWN_TIMEOUT":               &c.Shutdown.Timeout,
+		"HEALTH_CHECK_TIMEOUT":           &c.Health.CheckTimeout,
+		"HEALTH_INTERVAL":                &c.Health.Interval,
+		"TRASH_RETENTION":                &c.Trash.Retention,
+		"TRASH_PURGE_INTERVAL":           &c.Trash.PurgeInterval,
+		"OUTBOX_SINK":                    &c.Outbox.Sink,
+		"OUTBOX_FILE":                    &c.Outbox.File,
+		"OUTBOX_BROKER_URL":              &c.Outbox.BrokerURL,
+		"OUTBOX_TOPIC":                   &c.Outbox.Topic,
+		"OUTBOX_INTERVAL":                &c.Outbox.Interval,
+		"OUTBOX_BATCH_SIZE":              &c.Outbox.BatchSize,
+		"OUTBOX_LEASE":                   &c.Outbox.Lease,
+		"OUTBOX_RETRY_BACKOFF":           &c.Outbox.RetryBackoff,
+		"OUTBOX_MAX_RETRY_BACKOFF":       &c.Outbox.MaxRetryBackoff,
+		"OUTBOX_RETENTION":               &c.Outbox.Retention,
+		"WEBHOOKS_INTERVAL":              &c.Webhooks.Interval,
+		"WEBHOOKS_BATCH_SIZE":            &c.Webhooks.BatchSize,
+		"WEBHOOKS_LEASE":                 &c.Webhooks.Lease,
+		"WEBHOOKS_TIMEOUT":               &c.Webhooks.Timeout,
+		"WEBHOOKS_RETRY_BACKOFF":         &c.Webhooks.RetryBackoff,
+		"WEBHOOKS_MAX_RETRY_BACKOFF":     &c.Webhooks.MaxRetryBackoff,
+		"WEBHOOKS_MAX_ATTEMPTS":          &c.Webhooks.MaxAttempts,
+		"WEBHOOKS_DISABLE_AFTER":         &c.Webhooks.DisableAfter,
+		"WEBHOOKS_ALLOW_PRIVATE_TARGETS": &c.Webhooks.AllowPrivateTargets,
+		"CHANGES_POLL_INTERVAL":          &c.Changes.PollInterval,
+		"CHANGES_HEARTBEAT":              &c.Changes.Heartbeat,
+		"LOG_LEVEL":                      &c.Log.Level,
+		"LOG_FORMAT":                     &c.Log.Format,
+		"TRACING_EXPORTER":               &c.Tracing.Exporter,
+		"TRACING_ENDPOINT":               &c.Tracing.Endpoint,
+		"TRACING_INSECURE":               &c.Tracing.Insecure,
+		"TRACING_FILE":                   &c.Tracing.File,
+		"TRACING_SAMPLE_RATIO":           &c.Tracing.SampleRatio,
+	}
+}
+
+func (c *Config) applyEnv() error {
+	for name, target := range c.envOverrides() {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setValue(target, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setValue(target any, value string) error {
+	switch t := target.(type) {
+	case *string:
+		*t = value
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*t = n
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*t = b
+	case *float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*t = f
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*t = d
+	default:
+		return fmt.Errorf("unsupported type %T", target)
+	}
+	return nil
+}
+
+// Validate reports every missing or out-of-range setting at once.
+func (c *Config) Validate() error {
+	var problems []error
+	switch c.Storage {
+	case StoragePostgres:
+		if c.Database.DSN == "" {
+			problems = append(problems, errors.New("database.dsn is required"))
+		}
+	case StorageMemory:
+	default:
+		problems = append(problems, fmt.Errorf("storage must be %q or %q, got %q", StoragePostgres, StorageMemory, c.Storage))
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		problems = append(problems, errors.New("database pool sizes must not be negative"))
+	}
+	if c.HTTP.Addr == "" {
+		problems = append(problems, errors.New("http.addr is required"))
+	}
+	if c.GRPC.Addr == "" {
+		problems = append(problems, errors.New("grpc.addr is required"))
+	}
+	if c.Shutdown.Timeout <= 0 {
+		problems = append(problems, errors.New("shutdown.timeout must be positive"))
+	}
+	if c.Health.Interval <= 0 {
+		problems = append(problems, errors.New("health.interval must be positive"))
+	}
+	if c.Trash.Retention < 0 {
+		problems = append(problems, errors.New("trash.retention must not be negative"))
+	}
+	if c.Trash.Retention > 0 && c.Trash.PurgeInterval <= 0 {
+		problems = append(problems, errors.New("trash.purge_interval must be positive"))
+	}
+	problems = append(problems, c.Outbox.validate()...)
+	problems = append(problems, c.Webhooks.validate()...)
+	if c.Changes.PollInterval <= 0 || c.Changes.Heartbeat <= 0 {
+		problems = append(problems, errors.New("changes.poll_interval and changes.heartbeat must be positive"))
+	}
+	if err := new(slog.Level).UnmarshalText([]byte(c.Log.Level)); err != nil {
+		problems = append(problems, fmt.Errorf("log.level: unknown level %q", c.Log.Level))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		problems = append(problems, fmt.Errorf("log.format must be json or text, got %q", c.Log.Format))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Tracing.Endpoint == "" {
+			problems = append(problems, errors.New("tracing.endpoint is required for the otlp exporter"))
+		}
+	default:
+		problems = append(problems, fmt.Errorf("tracing.exporter must be none, otlp or stdout, got %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, errors.New("tracing.sample_ratio must be between 0 and 1"))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(problems...))
+	}
+	return nil
+}
+
+func (o *OutboxConfig) validate() []error {
+	var problems []error
+	switch o.Sink {
+	case OutboxSinkNone:
+	case OutboxSinkFile:
+		if o.File == "" {
+			problems = append(problems, errors.New("outbox.file is required for the file sink"))
+		}
+	case OutboxSinkKafkaREST:
+		if o.BrokerURL == "" || o.Topic == "" {
+			problems = append(problems, errors.New("outbox.broker_url and outbox.topic are required for the kafka_rest sink"))
+		}
+	default:
+		return []error{fmt.Errorf("outbox.sink must be %s, %s or %s, got %q",
+			OutboxSinkNone, OutboxSinkFile, OutboxSinkKafkaREST, o.Sink)}
+	}
+	if o.Interval <= 0 || o.BatchSize <= 0 || o.Lease <= 0 {
+		problems = append(problems, errors.New("outbox.interval, outbox.batch_size and outbox.lease must be positive"))
+	}
+	if o.RetryBackoff <= 0 || o.MaxRetryBackoff < o.RetryBackoff {
+		problems = append(problems, errors.New("outbox.retry_backoff must be positive and at most outbox.max_retry_backoff"))
+	}
+	if o.Retention < 0 {
+		problems = append(problems, errors.New("outbox.retention must not be negative"))
+	}
+	return problems
+}
+
+func (w *WebhooksConfig) validate() []error {
+	var problems []error
+	if w.Interval <= 0 || w.BatchSize <= 0 || w.Lease <= 0 || w.Timeout <= 0 {
+		problems = append(problems, errors.New("webhooks.interval, webhooks.batch_size, webhooks.lease and webhooks.timeout must be positive"))
+	}
+	if w.RetryBackoff <= 0 || w.MaxRetryBackoff < w.RetryBackoff {
+		problems = append(problems, errors.New("webhooks.retry_backoff must be positive and at most webhooks.max_retry_backoff"))
+	}
+	if w.MaxAttempts <= 0 {
+		problems = append(problems, errors.New("webhooks.max_attempts must be positive"))
+	}
+	if w.DisableAfter < 0 {
+		problems = append(problems, errors.New("webhooks.disable_after must not be negative"))
+	}
+	return problems
+}
@@ -0,0 +1,131 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies a domain error so that transports can map it to a status code.
+type Kind uint8
+
+const (
+	KindUnknown Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnavailable
+	// KindPrecondition reports that the resource changed since the version
+	// the caller based its request on.
+	KindPrecondition
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation failed"
+	case KindUnavailable:
+		return "unavailable"
+	case KindPrecondition:
+		return "precondition failed"
+	default:
+		return "internal error"
+	}
+}
+
+// Error is the error type produced by the repository and usecase layers.
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+}
+
+// Sentinels for use with errors.Is; they match any *Error of the same kind.
+var (
+	ErrNotFound     = &Error{Kind: KindNotFound}
+	ErrConflict     = &Error{Kind: KindConflict}
+	ErrValidation   = &Error{Kind: KindValidation}
+	ErrUnavailable  = &Error{Kind: KindUnavailable}
+	ErrPrecondition = &Error{Kind: KindPrecondition}
+)
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Kind.String()
+	}
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a bare sentinel of the same kind.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Message == "" && t.Err == nil && t.Kind == e.Kind
+}
+
+// KindOf returns the first classified kind in err's chain, or KindUnknown.
+func KindOf(err error) Kind {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			break
+		}
+		if e.Kind != KindUnknown {
+			return e.Kind
+		}
+		err = e.Err
+	}
+	return KindUnknown
+}
+
+// MessageOf returns the client-facing message of the first classified error
+// in err's chain, without the causes it wraps, which may carry driver details
+// such as constraint names or database addresses. Unclassified errors get
+// their kind's description.
+func MessageOf(err error) string {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			break
+		}
+		if e.Kind != KindUnknown {
+			if e.Message == "" {
+				return e.Kind.String()
+			}
+			return e.Message
+		}
+		err = e.Err
+	}
+	return KindUnknown.String()
+}
+
+func NotFound(format string, args ...any) error {
+	return &Error{Kind: KindNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+func Conflict(format string, args ...any) error {
+	return &Error{Kind: KindConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+func Validation(format string, args ...any) error {
+	return &Error{Kind: KindValidation, Message: fmt.Sprintf(format, args...)}
+}
+
+func PreconditionFailed(format string, args ...any) error {
+	return &Error{Kind: KindPrecondition, Message: fmt.Sprintf(format, args...)}
+}
+
+// Unavailable wraps err, typically a connectivity failure of a backing store.
+func Unavailable(err error, format string, args ...any) error {
+	return &Error{Kind: KindUnavailable, Message: fmt.Sprintf(format, args...), Err: err}
+}
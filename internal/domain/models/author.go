@@ -0,0 +1,68 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+type Author struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Role is the part a contributor played in a book.
+type Role string
+
+const (
+	RoleAuthor     Role = "author"
+	RoleEditor     Role = "editor"
+	RoleTranslator Role = "translator"
+)
+
+// Valid reports whether r is one of the supported roles.
+func (r Role) Valid() bool {
+	switch r {
+	case RoleAuthor, RoleEditor, RoleTranslator:
+		return true
+	}
+	return false
+}
+
+// Contributor links a book to an author in some role. A book's contributors
+// are kept in the order they were given.
+type Contributor struct {
+	// AuthorID refers to an existing author. When it is zero the author is
+	// looked up by Name and created if there is none yet.
+	AuthorID int    `json:"author_id,omitempty"`
+	Name     string `json:"name"`
+	Role     Role   `json:"role"`
+}
+
+// AuthorKey returns the identity of an author name: its letters and digits,
+// lowercased. "J. R. R. Tolkien" and "JRR Tolkien" share a key, so they are
+// the same author.
+func AuthorKey(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// ListAuthorsQuery selects one page of authors ordered by name.
+type ListAuthorsQuery struct {
+	NameContains string // case-insensitive substring
+	Limit        int
+	PageToken    string
+}
+
+// AuthorPage is one page of authors. NextPageToken is empty on the last page.
+type AuthorPage struct {
+	Authors       []*Author
+	NextPageToken string
+}
@@ -1,8 +1,33 @@
 package models
 
+import "time"
+
 type Book struct {
-	ID       int    `json:"id"`
-	Title    string `json:"title"`
-	Author   string `json:"author"`
-	BookYear int    `json:"year"`
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	// Author is the display line for the book's authors, derived from
+	// Contributors. Clients that only set Author get a single contributor
+	// with that name.
+	Author       string        `json:"author"`
+	Contributors []Contributor `json:"contributors,omitempty"`
+	BookYear     int           `json:"year"`
+	// ISBN is stored in its 13-digit form without separators; ISBN-10 input
+	// is converted. Empty when unknown; otherwise unique across books.
+	ISBN      string `json:"isbn,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	// Language is a canonical BCP 47 tag such as "en" or "pt-BR".
+	Language    string `json:"language,omitempty"`
+	Pages       int    `json:"pages,omitempty"`
+	Description string `json:"description,omitempty"`
+	Edition     string `json:"edition,omitempty"`
+	// CreatedAt and UpdatedAt are maintained by the repository; values
+	// supplied by clients are ignored.
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Version starts at 1 and is incremented whenever the book changes. On
+	// update it is the version the change was based on.
+	Version int `json:"version"`
+	// DeletedAt is set while the book is in the trash, from which it can be
+	// restored until it is purged.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// BookEventType names a domain event published for a book change.
+type BookEventType string
+
+const (
+	BookCreated  BookEventType = "BookCreated"
+	BookUpdated  BookEventType = "BookUpdated"
+	BookDeleted  BookEventType = "BookDeleted"
+	BookRestored BookEventType = "BookRestored"
+	BookPurged   BookEventType = "BookPurged"
+)
+
+// Valid reports whether t is one of the event types above.
+func (t BookEventType) Valid() bool {
+	switch t {
+	case BookCreated, BookUpdated, BookDeleted, BookRestored, BookPurged:
+		return true
+	}
+	return false
+}
+
+// eventTypes maps each history operation onto the event it publishes.
+var eventTypes = map[BookOperation]BookEventType{
+	OpCreate:  BookCreated,
+	OpUpdate:  BookUpdated,
+	OpDelete:  BookDeleted,
+	OpRestore: BookRestored,
+	OpPurge:   BookPurged,
+}
+
+// BookEvent is a domain event about a book, written to the outbox in the
+// transaction that makes the change and published from there at least once.
+// Consumers should expect duplicates and can tell them apart by ID.
+type BookEvent struct {
+	// ID orders events across all books; the events of one book are
+	// published in ID order.
+	ID     int           `json:"id"`
+	Type   BookEventType `json:"type"`
+	BookID int           `json:"book_id"`
+	// Version is the book's version after the change.
+	Version    int       `json:"version"`
+	Actor      string    `json:"actor"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Book is the book as it stood after the change; nil for purges.
+	Book    *Book         `json:"book,omitempty"`
+	Changes []FieldChange `json:"changes,omitempty"`
+
+	// Attempts counts the failed attempts to publish the event so far. It is
+	// delivery bookkeeping rather than part of the event.
+	Attempts int `json:"-"`
+}
+
+// NewBookEvent returns the event published for a history entry, leaving ID
+// to the outbox.
+func NewBookEvent(change *BookChange, book *Book) *BookEvent {
+	return &BookEvent{
+		Type:       eventTypes[change.Operation],
+		BookID:     change.BookID,
+		Version:    change.Version,
+		Actor:      change.Actor,
+		OccurredAt: change.ChangedAt,
+		Book:       book,
+		Changes:    change.Changes,
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import "slices"
+
+// BookField names a field clients can write, spelled as in JSON and protobuf.
+type BookField string
+
+const (
+	FieldTitle        BookField = "title"
+	FieldAuthor       BookField = "author"
+	FieldContributors BookField = "contributors"
+	FieldYear         BookField = "year"
+	FieldISBN         BookField = "isbn"
+	FieldPublisher    BookField = "publisher"
+	FieldLanguage     BookField = "language"
+	FieldPages        BookField = "pages"
+	FieldDescription  BookField = "description"
+	FieldEdition      BookField = "edition"
+)
+
+// BookFields lists every writable field.
+var BookFields = []BookField{
+	FieldTitle, FieldAuthor, FieldContributors, FieldYear, FieldISBN,
+	FieldPublisher, FieldLanguage, FieldPages, FieldDescription, FieldEdition,
+}
+
+// Valid reports whether f is a writable field.
+func (f BookField) Valid() bool {
+	return slices.Contains(BookFields, f)
+}
+
+// CopyFields sets the given fields of b to their values in src.
+func (b *Book) CopyFields(src *Book, fields []BookField) {
+	for _, f := range fields {
+		switch f {
+		case FieldTitle:
+			b.Title = src.Title
+		case FieldAuthor:
+			b.Author = src.Author
+		case FieldContributors:
+			b.Contributors = slices.Clone(src.Contributors)
+		case FieldYear:
+			b.BookYear = src.BookYear
+		case FieldISBN:
+			b.ISBN = src.ISBN
+		case FieldPublisher:
+			b.Publisher = src.Publisher
+		case FieldLanguage:
+			b.Language = src.Language
+		case FieldPages:
+			b.Pages = src.Pages
+		case FieldDescription:
+			b.Description = src.Description
+		case FieldEdition:
+			b.Edition = src.Edition
+		}
+	}
+}
+
+// ChangedFields returns the writable fields whose values differ between a and b.
+func ChangedFields(a, b *Book) []BookField {
+	var changed []BookField
+	for _, f := range BookFields {
+		var same bool
+		switch f {
+		case FieldTitle:
+			same = a.Title == b.Title
+		case FieldAuthor:
+			same = a.Author == b.Author
+		case FieldContributors:
+			same = slices.Equal(a.Contributors, b.Contributors)
+		case FieldYear:
+			same = a.BookYear == b.BookYear
+		case FieldISBN:
+			same = a.ISBN == b.ISBN
+		case FieldPublisher:
+			same = a.Publisher == b.Publisher
+		case FieldLanguage:
+			same = a.Language == b.Language
+		case FieldPages:
+			same = a.Pages == b.Pages
+		case FieldDescription:
+			same = a.Description == b.Description
+		case FieldEdition:
+			same = a.Edition == b.Edition
+		}
+		if !same {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+// FieldValue returns the value of field f of b, or nil if f isn't writable.
+func (b *Book) FieldValue(f BookField) any {
+	switch f {
+	case FieldTitle:
+		return b.Title
+	case FieldAuthor:
+		return b.Author
+	case FieldContributors:
+		return b.Contributors
+	case FieldYear:
+		return b.BookYear
+	case FieldISBN:
+		return b.ISBN
+	case FieldPublisher:
+		return b.Publisher
+	case FieldLanguage:
+		return b.Language
+	case FieldPages:
+		return b.Pages
+	case FieldDescription:
+		return b.Description
+	case FieldEdition:
+		return b.Edition
+	}
+	return nil
+}
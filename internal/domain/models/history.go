@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BookOperation names what a change did to a book.
+type BookOperation string
+
+const (
+	OpCreate  BookOperation = "create"
+	OpUpdate  BookOperation = "update"
+	OpDelete  BookOperation = "delete"
+	OpRestore BookOperation = "restore"
+	OpPurge   BookOperation = "purge"
+)
+
+// BookChange is one immutable entry of a book's history, written together
+// with the change it records.
+type BookChange struct {
+	// ID orders changes across all books.
+	ID     int `json:"id"`
+	BookID int `json:"book_id"`
+	// Version is the book's version after the change.
+	Version   int           `json:"version"`
+	Operation BookOperation `json:"operation"`
+	Actor     string        `json:"actor"`
+	ChangedAt time.Time     `json:"changed_at"`
+	// Changes lists the writable fields the change set, with their JSON
+	// values before and after. Deletes, restores and purges have none.
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is the before and after value of one field. Before is empty
+// on create.
+type FieldChange struct {
+	Field  BookField       `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after"`
+}
+
+// DiffBooks returns the writable fields that differ from before to after;
+// a nil before stands for a book that didn't exist, so every set field of
+// after is listed.
+func DiffBooks(before, after *Book) []FieldChange {
+	var changes []FieldChange
+	if before == nil {
+		before = &Book{}
+		for _, f := range ChangedFields(before, after) {
+			changes = append(changes, FieldChange{Field: f, After: jsonValue(after.FieldValue(f))})
+		}
+		return changes
+	}
+	for _, f := range ChangedFields(before, after) {
+		changes = append(changes, FieldChange{
+			Field:  f,
+			Before: jsonValue(before.FieldValue(f)),
+			After:  jsonValue(after.FieldValue(f)),
+		})
+	}
+	return changes
+}
+
+func jsonValue(v any) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
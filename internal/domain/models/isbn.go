@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	errISBNFormat   = errors.New("isbn must have 10 or 13 digits")
+	errISBNChecksum = errors.New("isbn check digit is wrong")
+	errISBNPrefix   = errors.New("isbn-13 must start with 978 or 979")
+)
+
+// NormalizeISBN validates an ISBN-10 or ISBN-13, which may contain hyphens
+// or spaces, and returns it as 13 digits without separators.
+func NormalizeISBN(s string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		if r == 'x' {
+			return 'X'
+		}
+		return r
+	}, s)
+
+	switch len(digits) {
+	case 10:
+		if !validISBN10(digits) {
+			return "", errISBNChecksum
+		}
+		body := "978" + digits[:9]
+		return body + string(isbn13CheckDigit(body)), nil
+	case 13:
+		if !allDigits(digits) {
+			return "", errISBNFormat
+		}
+		if !strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979") {
+			return "", errISBNPrefix
+		}
+		if isbn13CheckDigit(digits[:12]) != digits[12] {
+			return "", errISBNChecksum
+		}
+		return digits, nil
+	default:
+		return "", errISBNFormat
+	}
+}
+
+// validISBN10 checks digits weighted 10 down to 1 sum to a multiple of 11;
+// the last position may be X, standing for 10.
+func validISBN10(s string) bool {
+	if !allDigits(s[:9]) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(s[i]-'0') * (10 - i)
+	}
+	switch c := s[9]; {
+	case c == 'X':
+		sum += 10
+	case c >= '0' && c <= '9':
+		sum += int(c - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// isbn13CheckDigit computes the check digit for the first 12 digits of an
+// ISBN-13, whose digits are weighted alternately 1 and 3.
+func isbn13CheckDigit(body string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(body[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package models
+
+// SortField names a column books can be ordered by. Ties are always broken by ID.
+type SortField string
+
+const (
+	SortByID     SortField = "id"
+	SortByTitle  SortField = "title"
+	SortByAuthor SortField = "author"
+	SortByYear   SortField = "year"
+)
+
+// Valid reports whether f is one of the supported sort fields.
+func (f SortField) Valid() bool {
+	switch f {
+	case SortByID, SortByTitle, SortByAuthor, SortByYear:
+		return true
+	}
+	return false
+}
+
+// BookFilter narrows a listing; zero values are ignored.
+type BookFilter struct {
+	Author        string // any contributor with the same AuthorKey
+	AuthorID      int    // any contributor role
+	YearFrom      int    // inclusive
+	YearTo        int    // inclusive
+	TitleContains string // case-insensitive substring
+	ISBN          string // exact, in its canonical 13-digit form
+	// Deleted lists the trash instead of the live books.
+	Deleted bool
+}
+
+// ListBooksQuery selects one page of books.
+type ListBooksQuery struct {
+	Filter    BookFilter
+	SortBy    SortField
+	Desc      bool
+	Limit     int
+	PageToken string
+}
+
+// BookPage is one page of a listing. NextPageToken is empty on the last page.
+type BookPage struct {
+	Books         []*Book
+	NextPageToken string
+}
@@ -0,0 +1,26 @@
+package models
+
+// Markers wrapped around matched words in search highlights.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// SearchQuery is a free-text query over titles and authors.
+type SearchQuery struct {
+	Query string
+	Limit int
+}
+
+// SearchResult is a matching book with its relevance score and the title and
+// author with matched words wrapped in HighlightStart/HighlightStop.
+type SearchResult struct {
+	Book       *Book      `json:"book"`
+	Score      float64    `json:"score"`
+	Highlights Highlights `json:"highlights"`
+}
+
+type Highlights struct {
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Webhook is a subscription that pushes book events to a URL.
+type Webhook struct {
+	ID  int    `json:"id"`
+	URL string `json:"url"`
+	// EventTypes are the events delivered; empty means all of them.
+	EventTypes []BookEventType `json:"event_types"`
+	// Secret signs every delivery. It is write-only: responses omit it.
+	Secret string `json:"secret,omitempty"`
+	// FailureCount counts the failed delivery attempts since the last
+	// success. The webhook is disabled when it reaches the configured limit.
+	FailureCount int `json:"failure_count"`
+	// DisabledAt is set while the webhook is disabled; deliveries wait
+	// until it is enabled again.
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Wants reports whether events of type t are delivered to the webhook.
+func (w *Webhook) Wants(t BookEventType) bool {
+	return len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, t)
+}
+
+// DeliveryStatus is the state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed means the delivery ran out of attempts.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is the delivery of one event to one webhook, with the
+// outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID        int            `json:"id"`
+	WebhookID int            `json:"webhook_id"`
+	EventID   int            `json:"event_id"`
+	EventType BookEventType  `json:"event_type"`
+	BookID    int            `json:"book_id"`
+	Status    DeliveryStatus `json:"status"`
+	Attempts  int            `json:"attempts"`
+	// ResponseStatus is the HTTP status of the latest attempt; zero if it
+	// got no response.
+	ResponseStatus int    `json:"response_status,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	// Payload is the request body: the event as JSON.
+	Payload       json.RawMessage `json:"payload"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// DeliveryAttempt is the outcome of one attempt at a delivery.
+type DeliveryAttempt struct {
+	ResponseStatus int
+	// Error is empty when the attempt succeeded.
+	Error string
+	// RetryAt is when to try again after a failure; nil gives up on the
+	// delivery.
+	RetryAt *time.Time
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// AuthorRepository stores authors. Books link to them through their
+// contributors, which BookRepository resolves when a book is saved.
+type AuthorRepository interface {
+	// Create fails with a conflict when an author with the same
+	// models.AuthorKey exists.
+	Create(ctx context.Context, author *models.Author) error
+	List(ctx context.Context, query models.ListAuthorsQuery) (*models.AuthorPage, error)
+	FindByID(ctx context.Context, id int) (*models.Author, error)
+	// Update renames an author, refreshing the author line of its books.
+	Update(ctx context.Context, author *models.Author) error
+	// Delete fails with a conflict while any book still links to the author.
+	Delete(ctx context.Context, id int) error
+}
+
+// sortByName orders author listings; it is only ever stored in cursors.
+const sortByName models.SortField = "name"
+
+// NewAuthorCursor returns the cursor positioned after author in a listing
+// ordered by name and filtered by query.
+func NewAuthorCursor(query models.ListAuthorsQuery, author *models.Author) Cursor {
+	return Cursor{SortBy: sortByName, Value: author.Name, ID: author.ID, Filter: authorFilterHash(query)}
+}
+
+// DecodeAuthorCursor parses a page token issued by an author listing and
+// checks it was issued for the same filter as query.
+func DecodeAuthorCursor(token string, query models.ListAuthorsQuery) (*Cursor, error) {
+	return decodeCursor(token, sortByName, false, authorFilterHash(query))
+}
+
+// authorFilterHash returns the hash of the filter in query stored in cursors.
+func authorFilterHash(query models.ListAuthorsQuery) string {
+	if query.NameContains == "" {
+		return ""
+	}
+	return filterHash(strings.ToLower(query.NameContains))
+}
+
+// BookContributors returns book's contributors, with the role defaulting to
+// author, or, when it has none, a single author named after book.Author.
+func BookContributors(book *models.Book) []models.Contributor {
+	if len(book.Contributors) > 0 {
+		contributors := make([]models.Contributor, len(book.Contributors))
+		for i, c := range book.Contributors {
+			if c.Role == "" {
+				c.Role = models.RoleAuthor
+			}
+			contributors[i] = c
+		}
+		return contributors
+	}
+	if strings.TrimSpace(book.Author) == "" {
+		return nil
+	}
+	return []models.Contributor{{Name: strings.TrimSpace(book.Author), Role: models.RoleAuthor}}
+}
+
+// AuthorLine joins the names of the contributors in the author role, or of
+// every contributor when none is an author, into a book's display author.
+// The postgres adapter computes the same line in SQL when an author is renamed.
+func AuthorLine(contributors []models.Contributor) string {
+	var names, all []string
+	for _, c := range contributors {
+		all = append(all, c.Name)
+		if c.Role == models.RoleAuthor {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 {
+		names = all
+	}
+	return strings.Join(names, ", ")
+}
+
+// UnknownAuthor is returned when a contributor refers to a missing author.
+func UnknownAuthor(id int) error {
+	return errs.Validation("author %d does not exist", id)
+}
+
+// DuplicateContributor is returned when a book lists the same author twice
+// in one role.
+func DuplicateContributor(c models.Contributor) error {
+	if c.Name == "" {
+		return errs.Validation("author %d is listed more than once as %s", c.AuthorID, c.Role)
+	}
+	return errs.Validation("%s is listed more than once as %s", c.Name, c.Role)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// BookRepository is the storage port for books. Every adapter reports a missing
+// book with errs.ErrNotFound, including from Update and Delete, and returns
+// ctx.Err() once ctx is done.
+//
+// Delete moves a book to the trash: it keeps its ID and links but is hidden
+// from every method other than FindDeleted, Restore, Purge and List with
+// Filter.Deleted, as if it were missing. Deleting and restoring a book
+// each increment its version. ISBNs are only unique among live books, so
+// Restore fails with errs.ErrConflict when another book has taken the ISBN
+// meanwhile. Purge removes the books deleted before a cutoff for good and
+// returns how many there were.
+//
+// List receives a query already normalised by the usecase: SortBy is valid and
+// Limit is positive. Page tokens are decoded with DecodeCursor.
+//
+// Update and Delete apply only while the stored book is at the expected
+// version (book.Version for Update), failing with errs.ErrPrecondition
+// otherwise; a zero version applies unconditionally. Create starts books at
+// version 1 and every successful Update increments it.
+//
+// UpdateFields writes only the given fields of book, which must be valid
+// models.BookField values, under the same version rule as Update. Author and
+// contributors are resolved together, as in Create and Update, when either
+// is listed. On success book holds the whole stored book.
+//
+// Search ranks books by relevance across title and author, best first, and
+// matches word prefixes and small typos. Query is non-empty and Limit positive.
+//
+// Every change to a book, including the author line refreshes made by
+// AuthorRepository.Update, appends a models.BookChange to its history in the
+// same transaction, attributed to audit.Actor(ctx). History returns those
+// changes oldest first, and outlives purges; it fails with errs.ErrNotFound
+// for books that never existed. FindAsOf returns a book as it stood at a
+// given time, failing with errs.ErrNotFound if it didn't exist yet or was
+// deleted then.
+//
+// Changes returns up to limit changes to any book with an ID above afterID,
+// oldest first, as the events they published but numbered by change ID.
+// Change IDs become visible in order, so a reader that resumes after the
+// last ID it saw misses nothing.
+type BookRepository interface {
+	Create(ctx context.Context, book *models.Book) error
+	List(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error)
+	Search(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error)
+	FindByID(ctx context.Context, id int) (*models.Book, error)
+	Update(ctx context.Context, book *models.Book) error
+	UpdateFields(ctx context.Context, book *models.Book, fields []models.BookField) error
+	Delete(ctx context.Context, id, version int) error
+	FindDeleted(ctx context.Context, id int) (*models.Book, error)
+	Restore(ctx context.Context, id int) (*models.Book, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+	History(ctx context.Context, id int) ([]*models.BookChange, error)
+	FindAsOf(ctx context.Context, id int, at time.Time) (*models.Book, error)
+	Changes(ctx context.Context, afterID, limit int) ([]*models.BookEvent, error)
+}
+
+// VersionMismatch is returned when a book is no longer at the version a
+// caller expected.
+func VersionMismatch(id, current int) error {
+	return errs.PreconditionFailed("book %d has changed; its current version is %d", id, current)
+}
+
+// NotInTrash is returned when restoring a book that isn't deleted.
+func NotInTrash(id int) error {
+	return errs.NotFound("book %d is not in the trash", id)
+}
+
+// NotFoundAsOf is returned when a book didn't exist, or was deleted, at the
+// time it is looked up at.
+func NotFoundAsOf(id int, at time.Time) error {
+	return errs.NotFound("book %d did not exist at %s", id, at.Format(time.RFC3339))
+}
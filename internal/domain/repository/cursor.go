@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// Cursor is the keyset position encoded in a page token: the sort key and ID
+// of the last book on the previous page, and a hash of the filter the
+// listing was issued for.
+type Cursor struct {
+	SortBy models.SortField `json:"s"`
+	Desc   bool             `json:"d,omitempty"`
+	Value  string           `json:"v,omitempty"`
+	ID     int              `json:"i"`
+	Filter string           `json:"f,omitempty"`
+}
+
+// NewCursor returns the cursor positioned after book in a listing ordered
+// and filtered by query.
+func NewCursor(query models.ListBooksQuery, book *models.Book) Cursor {
+	return Cursor{
+		SortBy: query.SortBy,
+		Desc:   query.Desc,
+		Value:  SortValue(book, query.SortBy),
+		ID:     book.ID,
+		Filter: bookFilterHash(query.Filter),
+	}
+}
+
+// bookFilterHash returns the hash of filter stored in cursors, normalized as
+// the adapters match it; it is empty when nothing is filtered.
+func bookFilterHash(filter models.BookFilter) string {
+	if filter == (models.BookFilter{}) {
+		return ""
+	}
+	filter.Author = models.AuthorKey(filter.Author)
+	filter.TitleContains = strings.ToLower(filter.TitleContains)
+	return filterHash(filter)
+}
+
+// filterHash returns a short hash of the JSON form of filter.
+func filterHash(filter any) string {
+	data, _ := json.Marshal(filter)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:9])
+}
+
+// SortValue returns the value of book's sort key as stored in a cursor.
+func SortValue(book *models.Book, field models.SortField) string {
+	switch field {
+	case models.SortByTitle:
+		return book.Title
+	case models.SortByAuthor:
+		return book.Author
+	case models.SortByYear:
+		return strconv.Itoa(book.BookYear)
+	default:
+		return ""
+	}
+}
+
+// Encode returns the opaque page token for c.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a page token and checks it was issued for the same
+// ordering and filter as query.
+func DecodeCursor(token string, query models.ListBooksQuery) (*Cursor, error) {
+	return decodeCursor(token, query.SortBy, query.Desc, bookFilterHash(query.Filter))
+}
+
+func decodeCursor(token string, sortBy models.SortField, desc bool, filter string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errs.Validation("invalid page token")
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errs.Validation("invalid page token")
+	}
+	if c.SortBy != sortBy || c.Desc != desc {
+		return nil, errs.Validation("page token does not match the requested sort order")
+	}
+	if c.Filter != filter {
+		return nil, errs.Validation("page token does not match the requested filter")
+	}
+	if c.SortBy == models.SortByYear {
+		if _, err := strconv.Atoi(c.Value); err != nil {
+			return nil, errs.Validation("invalid page token")
+		}
+	}
+	return &c, nil
+}
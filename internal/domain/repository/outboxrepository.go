@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// OutboxRepository hands out the book events that BookRepository writes to
+// the outbox with every change, so that a relay can publish them.
+//
+// Claim returns up to limit unpublished events that are due, oldest first,
+// and hides them from other claims for lease; if the claimer dies, they
+// become due again when the lease runs out. An event is only claimed once
+// every earlier event of the same book is published, so the events of a
+// book are published in order.
+//
+// MarkPublished settles an event. MarkFailed counts a failed attempt,
+// records its error and makes the event due again at retryAt. Both fail
+// with errs.ErrNotFound for unknown events.
+//
+// DeletePublished removes the events published before publishedBefore and
+// returns how many there were.
+type OutboxRepository interface {
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*models.BookEvent, error)
+	MarkPublished(ctx context.Context, id int) error
+	MarkFailed(ctx context.Context, id int, reason string, retryAt time.Time) error
+	DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error)
+}
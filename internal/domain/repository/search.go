@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchTerms splits text into the lowercased words that search matches on,
+// so that every adapter tokenises queries the same way.
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// WebhookRepository stores webhook subscriptions and their deliveries.
+//
+// Enqueue adds a pending delivery of event to every enabled webhook that
+// wants it, at most once per webhook and event however often it is called,
+// and returns how many it added.
+//
+// ClaimDeliveries returns up to limit pending deliveries that are due, to
+// enabled webhooks, oldest first, and hides them from other claims for
+// lease. Like the outbox, a delivery is only claimed once the earlier
+// pending deliveries of its book to the same webhook are settled.
+//
+// RecordAttempt stores the outcome of an attempt. A success settles the
+// delivery and resets the webhook's FailureCount. A failure counts against
+// both, makes the delivery due again at attempt.RetryAt or fails it for
+// good when that is nil, and disables the webhook when its FailureCount
+// reaches disableAfter. It returns the webhook as updated.
+//
+// ListDeliveries returns the latest deliveries to a webhook, newest first.
+// Deleting a webhook deletes its deliveries.
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	List(ctx context.Context) ([]*models.Webhook, error)
+	FindByID(ctx context.Context, id int) (*models.Webhook, error)
+	Delete(ctx context.Context, id int) error
+	// Enable re-enables a webhook and resets its FailureCount.
+	Enable(ctx context.Context, id int) (*models.Webhook, error)
+
+	Enqueue(ctx context.Context, event *models.BookEvent) (int, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, id int, attempt models.DeliveryAttempt, disableAfter int) (*models.Webhook, error)
+	ListDeliveries(ctx context.Context, webhookID, limit int) ([]*models.WebhookDelivery, error)
+}
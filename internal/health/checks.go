@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// PingDB checks that a connection to db can be established.
+func PingDB(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// SchemaVersioner reports the applied and expected schema versions;
+// *migrate.Migrator implements it.
+type SchemaVersioner interface {
+	Current(ctx context.Context) (uint, error)
+	Latest() uint
+}
+
+// SchemaVersion checks that the database schema is at least at the version
+// this build expects, so that a replica isn't routed traffic before
+// migrations have run. A schema that is ahead passes: during a rolling
+// deploy the first new replica migrates while the old ones keep serving.
+func SchemaVersion(v SchemaVersioner) Check {
+	return func(ctx context.Context) error {
+		current, err := v.Current(ctx)
+		if err != nil {
+			return err
+		}
+		if latest := v.Latest(); current < latest {
+			return fmt.Errorf("schema is at version %d, expected %d", current, latest)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Watch runs the checks every interval and publishes the outcome on srv for
+// the whole server ("") and for each of services, until ctx is done.
+func (c *Checker) Watch(ctx context.Context, srv *grpchealth.Server, interval time.Duration, services ...string) {
+	services = append([]string{""}, services...)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if c.Run(ctx).Up() {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		for _, service := range services {
+			srv.SetServingStatus(service, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
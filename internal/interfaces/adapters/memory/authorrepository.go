@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+var _ repository.AuthorRepository = (*AuthorRepository)(nil)
+
+// AuthorRepository serves the authors held by a BookRepository, which links
+// them to books.
+type AuthorRepository struct {
+	store *BookRepository
+}
+
+func NewAuthorRepository(books *BookRepository) *AuthorRepository {
+	return &AuthorRepository{store: books}
+}
+
+func (r *AuthorRepository) Create(ctx context.Context, author *models.Author) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := models.AuthorKey(author.Name)
+	if _, taken := s.authorKeys[key]; taken {
+		return errs.Conflict("an author with this name already exists")
+	}
+	s.lastAuthorID++
+	author.ID = s.lastAuthorID
+	author.CreatedAt = now()
+	author.UpdatedAt = author.CreatedAt
+	s.authors[author.ID] = *author
+	s.authorKeys[key] = author.ID
+	return nil
+}
+
+func (r *AuthorRepository) List(ctx context.Context, query models.ListAuthorsQuery) (*models.AuthorPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var after *repository.Cursor
+	if query.PageToken != "" {
+		c, err := repository.DecodeAuthorCursor(query.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		after = c
+	}
+
+	s := r.store
+	s.mu.RLock()
+	authors := make([]*models.Author, 0, len(s.authors))
+	for _, author := range s.authors {
+		author := author
+		if query.NameContains == "" || strings.Contains(strings.ToLower(author.Name), strings.ToLower(query.NameContains)) {
+			authors = append(authors, &author)
+		}
+	}
+	s.mu.RUnlock()
+
+	// Ordered like name COLLATE "C", id in the postgres adapter.
+	compareAuthor := func(a *models.Author, name string, id int) int {
+		if c := strings.Compare(a.Name, name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, id)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		return compareAuthor(authors[i], authors[j].Name, authors[j].ID) < 0
+	})
+	if after != nil {
+		start := sort.Search(len(authors), func(i int) bool {
+			return compareAuthor(authors[i], after.Value, after.ID) > 0
+		})
+		authors = authors[start:]
+	}
+
+	page := &models.AuthorPage{Authors: authors}
+	if len(authors) > query.Limit {
+		page.Authors = authors[:query.Limit]
+		page.NextPageToken = repository.NewAuthorCursor(query, page.Authors[query.Limit-1]).Encode()
+	}
+	return page, nil
+}
+
+func (r *AuthorRepository) FindByID(ctx context.Context, id int) (*models.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	author, ok := s.authors[id]
+	if !ok {
+		return nil, errs.NotFound("author %d not found", id)
+	}
+	return &author, nil
+}
+
+func (r *AuthorRepository) Update(ctx context.Context, author *models.Author) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.authors[author.ID]
+	if !ok {
+		return errs.NotFound("author %d not found", author.ID)
+	}
+	oldKey, key := models.AuthorKey(old.Name), models.AuthorKey(author.Name)
+	if id, taken := s.authorKeys[key]; taken && id != author.ID {
+		return errs.Conflict("an author with this name already exists")
+	}
+
+	author.CreatedAt = old.CreatedAt
+	author.UpdatedAt = now()
+	delete(s.authorKeys, oldKey)
+	s.authorKeys[key] = author.ID
+	s.authors[author.ID] = *author
+
+	// Books in the trash are rewritten too, so that a restored book carries
+	// its authors' current names, but their change isn't recorded. They are
+	// walked in id order, as the postgres adapter records them.
+	var ids []int
+	for id, book := range s.books {
+		if hasContributor(&book, func(c models.Contributor) bool { return c.AuthorID == author.ID }) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		book := s.books[id]
+		s.index.remove(&book)
+		updated := clone(book)
+		for i := range updated.Contributors {
+			if updated.Contributors[i].AuthorID == author.ID {
+				updated.Contributors[i].Name = author.Name
+			}
+		}
+		updated.Author = repository.AuthorLine(updated.Contributors)
+		updated.Version++
+		updated.UpdatedAt = author.UpdatedAt
+		s.books[id] = *updated
+		if updated.DeletedAt == nil {
+			s.index.add(updated)
+			s.record(ctx, models.OpUpdate, &book, updated)
+		}
+	}
+	return nil
+}
+
+func (r *AuthorRepository) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	author, ok := s.authors[id]
+	if !ok {
+		return errs.NotFound("author %d not found", id)
+	}
+	for _, book := range s.books {
+		if hasContributor(&book, func(c models.Contributor) bool { return c.AuthorID == id }) {
+			return errs.Conflict("author still has books")
+		}
+	}
+	delete(s.authorKeys, models.AuthorKey(author.Name))
+	delete(s.authors, id)
+	return nil
+}
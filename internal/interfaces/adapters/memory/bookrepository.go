@@ -0,0 +1,531 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+var _ repository.BookRepository = (*BookRepository)(nil)
+
+// BookRepository keeps books, and the authors they link to, in process
+// memory. Like a SERIAL column, IDs start at 1 and are never reused, even
+// after a delete.
+type BookRepository struct {
+	mu     sync.RWMutex
+	books  map[int]models.Book
+	index  *searchIndex
+	lastID int
+
+	authors      map[int]models.Author
+	authorKeys   map[string]int
+	lastAuthorID int
+
+	changes []historyEntry // every change, in ID order
+	history map[int][]int  // indexes into changes, by book
+
+	outbox      []outboxEntry
+	lastEventID int
+}
+
+// historyEntry is a change together with the book as it stood after it.
+type historyEntry struct {
+	change models.BookChange
+	book   *models.Book // nil for purges
+}
+
+func NewBookRepository() *BookRepository {
+	return &BookRepository{
+		books:      make(map[int]models.Book),
+		index:      newSearchIndex(),
+		authors:    make(map[int]models.Author),
+		authorKeys: make(map[string]int),
+		history:    make(map[int][]int),
+	}
+}
+
+func (r *BookRepository) Create(ctx context.Context, book *models.Book) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkISBN(book); err != nil {
+		return err
+	}
+	contributors, err := r.resolveContributors(repository.BookContributors(book))
+	if err != nil {
+		return err
+	}
+	r.lastID++
+	book.ID = r.lastID
+	book.Contributors = contributors
+	book.Author = repository.AuthorLine(contributors)
+	book.CreatedAt = now()
+	book.UpdatedAt = book.CreatedAt
+	book.Version = 1
+	r.books[book.ID] = *clone(*book)
+	r.index.add(book)
+	r.record(ctx, models.OpCreate, nil, book)
+	return nil
+}
+
+func (r *BookRepository) List(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var after *repository.Cursor
+	if query.PageToken != "" {
+		c, err := repository.DecodeCursor(query.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		after = c
+	}
+
+	r.mu.RLock()
+	books := make([]*models.Book, 0, len(r.books))
+	for _, book := range r.books {
+		if matches(&book, query.Filter) {
+			books = append(books, clone(book))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(books, func(i, j int) bool {
+		return compare(books[i], books[j].ID, repository.SortValue(books[j], query.SortBy), query) < 0
+	})
+
+	if after != nil {
+		start := sort.Search(len(books), func(i int) bool {
+			return compare(books[i], after.ID, after.Value, query) > 0
+		})
+		books = books[start:]
+	}
+
+	page := &models.BookPage{Books: books}
+	if len(books) > query.Limit {
+		page.Books = books[:query.Limit]
+		page.NextPageToken = repository.NewCursor(query, page.Books[query.Limit-1]).Encode()
+	}
+	return page, nil
+}
+
+// compare orders book relative to the position (id, value) in the direction
+// requested by query, mirroring ORDER BY <key>, id in the postgres adapter.
+func compare(book *models.Book, id int, value string, query models.ListBooksQuery) int {
+	c := 0
+	switch query.SortBy {
+	case models.SortByYear:
+		year, _ := strconv.Atoi(value)
+		c = cmp.Compare(book.BookYear, year)
+	case models.SortByTitle, models.SortByAuthor:
+		c = strings.Compare(repository.SortValue(book, query.SortBy), value)
+	}
+	if c == 0 {
+		c = cmp.Compare(book.ID, id)
+	}
+	if query.Desc {
+		return -c
+	}
+	return c
+}
+
+func matches(book *models.Book, f models.BookFilter) bool {
+	if (book.DeletedAt != nil) != f.Deleted {
+		return false
+	}
+	if f.Author != "" && !hasContributor(book, func(c models.Contributor) bool {
+		return models.AuthorKey(c.Name) == models.AuthorKey(f.Author)
+	}) {
+		return false
+	}
+	if f.AuthorID != 0 && !hasContributor(book, func(c models.Contributor) bool {
+		return c.AuthorID == f.AuthorID
+	}) {
+		return false
+	}
+	if f.YearFrom != 0 && book.BookYear < f.YearFrom {
+		return false
+	}
+	if f.YearTo != 0 && book.BookYear > f.YearTo {
+		return false
+	}
+	if f.TitleContains != "" && !strings.Contains(strings.ToLower(book.Title), strings.ToLower(f.TitleContains)) {
+		return false
+	}
+	if f.ISBN != "" && book.ISBN != f.ISBN {
+		return false
+	}
+	return true
+}
+
+func hasContributor(book *models.Book, pred func(models.Contributor) bool) bool {
+	for _, c := range book.Contributors {
+		if pred(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *BookRepository) FindByID(ctx context.Context, id int) (*models.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt != nil {
+		return nil, errs.NotFound("book %d not found", id)
+	}
+	return clone(book), nil
+}
+
+func (r *BookRepository) Update(ctx context.Context, book *models.Book) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, err := r.current(book.ID, book.Version)
+	if err != nil {
+		return err
+	}
+	return r.replace(ctx, old, book)
+}
+
+func (r *BookRepository) UpdateFields(ctx context.Context, book *models.Book, fields []models.BookField) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, err := r.current(book.ID, book.Version)
+	if err != nil {
+		return err
+	}
+	merged := clone(old)
+	merged.CopyFields(book, fields)
+	if err := r.replace(ctx, old, merged); err != nil {
+		return err
+	}
+	*book = *merged
+	return nil
+}
+
+// current returns the live book id, checking that it is at version unless
+// that is zero. The caller must hold the lock.
+func (r *BookRepository) current(id, version int) (models.Book, error) {
+	old, ok := r.books[id]
+	if !ok || old.DeletedAt != nil {
+		return models.Book{}, errs.NotFound("book %d not found", id)
+	}
+	if version != 0 && version != old.Version {
+		return models.Book{}, repository.VersionMismatch(id, old.Version)
+	}
+	return old, nil
+}
+
+// replace stores book in place of old as its next version. The caller must
+// hold the write lock.
+func (r *BookRepository) replace(ctx context.Context, old models.Book, book *models.Book) error {
+	if err := r.checkISBN(book); err != nil {
+		return err
+	}
+	contributors, err := r.resolveContributors(repository.BookContributors(book))
+	if err != nil {
+		return err
+	}
+	book.Contributors = contributors
+	book.Author = repository.AuthorLine(contributors)
+	book.CreatedAt = old.CreatedAt
+	book.UpdatedAt = now()
+	book.Version = old.Version + 1
+	r.index.remove(&old)
+	r.books[book.ID] = *clone(*book)
+	r.index.add(book)
+	r.record(ctx, models.OpUpdate, &old, book)
+	return nil
+}
+
+// record appends the change from before to after, which is nil for creates,
+// to the history of the book. The caller must hold the write lock.
+func (r *BookRepository) record(ctx context.Context, op models.BookOperation, before, after *models.Book) {
+	r.appendChange(models.BookChange{
+		BookID:    after.ID,
+		Version:   after.Version,
+		Operation: op,
+		Actor:     audit.Actor(ctx),
+		Changes:   models.DiffBooks(before, after),
+	}, clone(*after))
+}
+
+// appendChange adds change to the history of its book, with book as it
+// stood afterwards, and queues its event in the outbox. The caller must
+// hold the write lock.
+func (r *BookRepository) appendChange(change models.BookChange, book *models.Book) {
+	change.ID = len(r.changes) + 1
+	change.ChangedAt = now()
+	r.history[change.BookID] = append(r.history[change.BookID], len(r.changes))
+	r.changes = append(r.changes, historyEntry{change: change, book: book})
+
+	r.lastEventID++
+	event := models.NewBookEvent(&change, book)
+	event.ID = r.lastEventID
+	r.outbox = append(r.outbox, outboxEntry{event: *event, dueAt: change.ChangedAt})
+}
+
+// checkISBN enforces the uniqueness of ISBNs among live books, like the
+// partial books_isbn_key index. The caller must hold the write lock.
+func (r *BookRepository) checkISBN(book *models.Book) error {
+	if book.ISBN == "" {
+		return nil
+	}
+	for id, other := range r.books {
+		if id != book.ID && other.DeletedAt == nil && other.ISBN == book.ISBN {
+			return errs.Conflict("a book with this isbn already exists")
+		}
+	}
+	return nil
+}
+
+// resolveContributors fills in the author ID and stored name of each
+// contributor, creating authors looked up by a name nobody has yet. Nothing
+// is created unless every contributor resolves. The caller must hold the
+// write lock.
+func (r *BookRepository) resolveContributors(contributors []models.Contributor) ([]models.Contributor, error) {
+	type link struct {
+		authorID int
+		role     models.Role
+	}
+	var (
+		resolved = make([]models.Contributor, len(contributors))
+		created  = map[int]string{}
+		pending  = map[string]int{}
+		seen     = map[link]bool{}
+		lastID   = r.lastAuthorID
+	)
+	for i, c := range contributors {
+		if c.AuthorID == 0 {
+			key := models.AuthorKey(c.Name)
+			id, ok := r.authorKeys[key]
+			if !ok {
+				if id, ok = pending[key]; !ok {
+					lastID++
+					id = lastID
+					pending[key] = id
+					created[id] = c.Name
+				}
+			}
+			c.AuthorID = id
+		} else if _, ok := r.authors[c.AuthorID]; !ok {
+			return nil, repository.UnknownAuthor(c.AuthorID)
+		}
+		if seen[link{c.AuthorID, c.Role}] {
+			return nil, repository.DuplicateContributor(c)
+		}
+		seen[link{c.AuthorID, c.Role}] = true
+		resolved[i] = c
+	}
+
+	for key, id := range pending {
+		t := now()
+		r.authors[id] = models.Author{ID: id, Name: created[id], CreatedAt: t, UpdatedAt: t}
+		r.authorKeys[key] = id
+	}
+	r.lastAuthorID = lastID
+	for i := range resolved {
+		resolved[i].Name = r.authors[resolved[i].AuthorID].Name
+	}
+	return resolved, nil
+}
+
+// clone copies book so that callers can't alias stored state.
+func clone(book models.Book) *models.Book {
+	book.Contributors = slices.Clone(book.Contributors)
+	return &book
+}
+
+// now returns the current time at the precision Postgres stores.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func (r *BookRepository) Delete(ctx context.Context, id, version int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, err := r.current(id, version)
+	if err != nil {
+		return err
+	}
+	r.index.remove(&old)
+	deleted := clone(old)
+	t := now()
+	deleted.DeletedAt = &t
+	deleted.UpdatedAt = t
+	deleted.Version++
+	r.books[id] = *deleted
+	r.record(ctx, models.OpDelete, &old, deleted)
+	return nil
+}
+
+func (r *BookRepository) FindDeleted(ctx context.Context, id int) (*models.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt == nil {
+		return nil, repository.NotInTrash(id)
+	}
+	return clone(book), nil
+}
+
+func (r *BookRepository) Restore(ctx context.Context, id int) (*models.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt == nil {
+		return nil, repository.NotInTrash(id)
+	}
+	if err := r.checkISBN(&book); err != nil {
+		return nil, err
+	}
+	restored := clone(book)
+	restored.DeletedAt = nil
+	restored.UpdatedAt = now()
+	restored.Version++
+	r.books[id] = *restored
+	r.index.add(restored)
+	r.record(ctx, models.OpRestore, &book, restored)
+	return clone(*restored), nil
+}
+
+func (r *BookRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Purged in id order, as the postgres adapter does, so that the changes
+	// are recorded in the same order.
+	var expired []int
+	for id, book := range r.books {
+		if book.DeletedAt != nil && book.DeletedAt.Before(deletedBefore) {
+			expired = append(expired, id)
+		}
+	}
+	sort.Ints(expired)
+	for _, id := range expired {
+		book := r.books[id]
+		delete(r.books, id)
+		r.appendChange(models.BookChange{
+			BookID:    id,
+			Version:   book.Version,
+			Operation: models.OpPurge,
+			Actor:     audit.Actor(ctx),
+		}, nil)
+	}
+	return len(expired), nil
+}
+
+func (r *BookRepository) History(ctx context.Context, id int) ([]*models.BookChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.history[id]
+	if len(entries) == 0 {
+		return nil, errs.NotFound("book %d not found", id)
+	}
+	changes := make([]*models.BookChange, len(entries))
+	for i, j := range entries {
+		change := r.changes[j].change
+		change.Changes = slices.Clone(change.Changes)
+		changes[i] = &change
+	}
+	return changes, nil
+}
+
+func (r *BookRepository) FindAsOf(ctx context.Context, id int, at time.Time) (*models.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.history[id]
+	i := sort.Search(len(entries), func(i int) bool { return r.changes[entries[i]].change.ChangedAt.After(at) })
+	if i == 0 {
+		return nil, repository.NotFoundAsOf(id, at)
+	}
+	book := r.changes[entries[i-1]].book
+	if book == nil || book.DeletedAt != nil {
+		return nil, repository.NotFoundAsOf(id, at)
+	}
+	return clone(*book), nil
+}
+
+func (r *BookRepository) Changes(ctx context.Context, afterID, limit int) ([]*models.BookEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Change IDs are positions in r.changes, counting from 1.
+	start := min(max(afterID, 0), len(r.changes))
+	entries := r.changes[start:min(start+limit, len(r.changes))]
+	events := make([]*models.BookEvent, len(entries))
+	for i, e := range entries {
+		change := e.change
+		change.Changes = slices.Clone(change.Changes)
+		var book *models.Book
+		if e.book != nil {
+			book = clone(*e.book)
+		}
+		events[i] = models.NewBookEvent(&change, book)
+		events[i].ID = change.ID
+	}
+	return events, nil
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+var _ repository.OutboxRepository = (*OutboxRepository)(nil)
+
+// outboxEntry is an event together with its delivery bookkeeping.
+type outboxEntry struct {
+	event       models.BookEvent
+	dueAt       time.Time
+	publishedAt *time.Time
+	lastError   string
+}
+
+// OutboxRepository serves the events that a BookRepository queues with
+// every change.
+type OutboxRepository struct {
+	store *BookRepository
+}
+
+func NewOutboxRepository(books *BookRepository) *OutboxRepository {
+	return &OutboxRepository{store: books}
+}
+
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*models.BookEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := now()
+	var events []*models.BookEvent
+	waiting := make(map[int]bool) // books with an earlier unpublished event
+	for i := range s.outbox {
+		if len(events) == limit {
+			break
+		}
+		e := &s.outbox[i]
+		if e.publishedAt != nil {
+			continue
+		}
+		if !waiting[e.event.BookID] && !e.dueAt.After(t) {
+			e.dueAt = t.Add(lease)
+			event := e.event
+			events = append(events, &event)
+		}
+		waiting[e.event.BookID] = true
+	}
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int) error {
+	return r.update(ctx, id, func(e *outboxEntry) {
+		t := now()
+		e.publishedAt = &t
+	})
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int, reason string, retryAt time.Time) error {
+	return r.update(ctx, id, func(e *outboxEntry) {
+		e.event.Attempts++
+		e.lastError = reason
+		e.dueAt = retryAt
+	})
+}
+
+func (r *OutboxRepository) update(ctx context.Context, id int, fn func(*outboxEntry)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The outbox is in ID order.
+	i := sort.Search(len(s.outbox), func(i int) bool { return s.outbox[i].event.ID >= id })
+	if i == len(s.outbox) || s.outbox[i].event.ID != id {
+		return errs.NotFound("event %d not found", id)
+	}
+	fn(&s.outbox[i])
+	return nil
+}
+
+func (r *OutboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.outbox[:0]
+	for _, e := range s.outbox {
+		if e.publishedAt == nil || !e.publishedAt.Before(publishedBefore) {
+			kept = append(kept, e)
+		}
+	}
+	deleted := len(s.outbox) - len(kept)
+	clear(s.outbox[len(kept):])
+	s.outbox = kept
+	return deleted, nil
+}
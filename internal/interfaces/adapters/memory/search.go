@@ -0,0 +1,235 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+type field uint8
+
+const (
+	fieldTitle field = 1 << iota
+	fieldAuthor
+)
+
+// Relevance weights: titles count more than authors, mirroring the A/B
+// weights of the postgres tsvector, and closer matches count more.
+const (
+	titleWeight  = 1.0
+	authorWeight = 0.4
+	exactMatch   = 1.0
+	prefixMatch  = 0.75
+	fuzzyMatch   = 0.5
+)
+
+// searchIndex is an inverted index from words to the books and fields they occur in.
+type searchIndex struct {
+	postings map[string]map[int]field
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{postings: make(map[string]map[int]field)}
+}
+
+func (ix *searchIndex) add(book *models.Book) {
+	ix.each(book, func(word string, f field) {
+		ids, ok := ix.postings[word]
+		if !ok {
+			ids = make(map[int]field)
+			ix.postings[word] = ids
+		}
+		ids[book.ID] |= f
+	})
+}
+
+func (ix *searchIndex) remove(book *models.Book) {
+	ix.each(book, func(word string, _ field) {
+		delete(ix.postings[word], book.ID)
+		if len(ix.postings[word]) == 0 {
+			delete(ix.postings, word)
+		}
+	})
+}
+
+func (ix *searchIndex) each(book *models.Book, fn func(word string, f field)) {
+	for _, word := range repository.SearchTerms(book.Title) {
+		fn(word, fieldTitle)
+	}
+	for _, word := range repository.SearchTerms(book.Author) {
+		fn(word, fieldAuthor)
+	}
+}
+
+// search scores every book that matches all terms and returns the scores
+// along with the indexed words that matched.
+func (ix *searchIndex) search(terms []string) (map[int]float64, map[string]bool) {
+	scores := map[int]float64{}
+	matched := map[string]bool{}
+
+	for i, term := range terms {
+		best := map[int]float64{}
+		for word, ids := range ix.postings {
+			m := matchScore(term, word)
+			if m == 0 {
+				continue
+			}
+			matched[word] = true
+			for id, f := range ids {
+				s := m * authorWeight
+				if f&fieldTitle != 0 {
+					s = m * titleWeight
+				}
+				if s > best[id] {
+					best[id] = s
+				}
+			}
+		}
+
+		if i == 0 {
+			scores = best
+			continue
+		}
+		for id := range scores {
+			if s, ok := best[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores, matched
+}
+
+func matchScore(term, word string) float64 {
+	switch {
+	case word == term:
+		return exactMatch
+	case strings.HasPrefix(word, term):
+		return prefixMatch
+	case withinEdits(term, word, maxEdits(term)):
+		return fuzzyMatch
+	}
+	return 0
+}
+
+// maxEdits is the number of typos tolerated in a search term of that length.
+func maxEdits(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// withinEdits reports whether a and b are at most max edits apart, counting
+// insertions, deletions, substitutions and adjacent transpositions
+// (optimal string alignment distance).
+func withinEdits(a, b string, max int) bool {
+	if max == 0 {
+		return false
+	}
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > max || -d > max {
+		return false
+	}
+
+	prevPrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	return prev[len(rb)] <= max
+}
+
+// highlight wraps the words of text that are in matched with the highlight markers.
+func highlight(text string, matched map[string]bool) string {
+	var b strings.Builder
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+	for len(text) > 0 {
+		end := strings.IndexFunc(text, func(r rune) bool { return !isWord(r) })
+		if end == 0 {
+			next := strings.IndexFunc(text, isWord)
+			if next < 0 {
+				next = len(text)
+			}
+			b.WriteString(text[:next])
+			text = text[next:]
+			continue
+		}
+		if end < 0 {
+			end = len(text)
+		}
+		word := text[:end]
+		if matched[strings.ToLower(word)] {
+			b.WriteString(models.HighlightStart + word + models.HighlightStop)
+		} else {
+			b.WriteString(word)
+		}
+		text = text[end:]
+	}
+	return b.String()
+}
+
+func (r *BookRepository) Search(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	terms := repository.SearchTerms(query.Query)
+	if len(terms) == 0 {
+		return []*models.SearchResult{}, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	scores, matched := r.index.search(terms)
+	results := make([]*models.SearchResult, 0, len(scores))
+	for id, score := range scores {
+		book := clone(r.books[id])
+		results = append(results, &models.SearchResult{
+			Book:  book,
+			Score: score,
+			Highlights: models.Highlights{
+				Title:  highlight(book.Title, matched),
+				Author: highlight(book.Author, matched),
+			},
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Book.ID < results[j].Book.ID
+	})
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
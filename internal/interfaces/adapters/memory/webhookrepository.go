@@ -0,0 +1,281 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+var _ repository.WebhookRepository = (*WebhookRepository)(nil)
+
+// WebhookRepository keeps webhooks and their deliveries in memory.
+type WebhookRepository struct {
+	mu           sync.Mutex
+	webhooks     map[int]models.Webhook
+	lastID       int
+	deliveries   []deliveryEntry // in ID order
+	lastDelivery int
+	enqueued     map[webhookEvent]bool
+}
+
+// deliveryEntry is a delivery together with when it is next due.
+type deliveryEntry struct {
+	delivery models.WebhookDelivery
+	dueAt    time.Time
+}
+
+type webhookEvent struct{ webhookID, eventID int }
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		webhooks: make(map[int]models.Webhook),
+		enqueued: make(map[webhookEvent]bool),
+	}
+}
+
+func cloneWebhook(w models.Webhook) *models.Webhook {
+	w.EventTypes = slices.Clone(w.EventTypes)
+	return &w
+}
+
+func cloneDelivery(d models.WebhookDelivery) *models.WebhookDelivery {
+	d.Payload = slices.Clone(d.Payload)
+	return &d
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	webhook.ID = r.lastID
+	webhook.FailureCount = 0
+	webhook.DisabledAt = nil
+	webhook.CreatedAt = now()
+	webhook.UpdatedAt = webhook.CreatedAt
+	r.webhooks[webhook.ID] = *cloneWebhook(*webhook)
+	return nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]*models.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks := make([]*models.Webhook, 0, len(r.webhooks))
+	for _, w := range r.webhooks {
+		webhooks = append(webhooks, cloneWebhook(w))
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id int) (*models.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.webhooks[id]
+	if !ok {
+		return nil, errs.NotFound("webhook %d not found", id)
+	}
+	return cloneWebhook(w), nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return errs.NotFound("webhook %d not found", id)
+	}
+	delete(r.webhooks, id)
+	kept := r.deliveries[:0]
+	for _, e := range r.deliveries {
+		if e.delivery.WebhookID == id {
+			delete(r.enqueued, webhookEvent{id, e.delivery.EventID})
+			continue
+		}
+		kept = append(kept, e)
+	}
+	clear(r.deliveries[len(kept):])
+	r.deliveries = kept
+	return nil
+}
+
+func (r *WebhookRepository) Enable(ctx context.Context, id int) (*models.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.webhooks[id]
+	if !ok {
+		return nil, errs.NotFound("webhook %d not found", id)
+	}
+	w.DisabledAt = nil
+	w.FailureCount = 0
+	w.UpdatedAt = now()
+	r.webhooks[id] = w
+	return cloneWebhook(w), nil
+}
+
+func (r *WebhookRepository) Enqueue(ctx context.Context, event *models.BookEvent) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]int, 0, len(r.webhooks))
+	for id, w := range r.webhooks {
+		if w.DisabledAt == nil && w.Wants(event.Type) && !r.enqueued[webhookEvent{id, event.ID}] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	t := now()
+	for _, id := range ids {
+		r.lastDelivery++
+		r.enqueued[webhookEvent{id, event.ID}] = true
+		due := t
+		r.deliveries = append(r.deliveries, deliveryEntry{
+			delivery: models.WebhookDelivery{
+				ID:            r.lastDelivery,
+				WebhookID:     id,
+				EventID:       event.ID,
+				EventType:     event.Type,
+				BookID:        event.BookID,
+				Status:        models.DeliveryPending,
+				Payload:       payload,
+				NextAttemptAt: &due,
+				CreatedAt:     t,
+			},
+			dueAt: t,
+		})
+	}
+	return len(ids), nil
+}
+
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	type webhookBook struct{ webhookID, bookID int }
+	t := now()
+	var deliveries []*models.WebhookDelivery
+	waiting := make(map[webhookBook]bool) // with an earlier pending delivery
+	for i := range r.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		e := &r.deliveries[i]
+		d := &e.delivery
+		if d.Status != models.DeliveryPending {
+			continue
+		}
+		key := webhookBook{d.WebhookID, d.BookID}
+		if !waiting[key] && r.webhooks[d.WebhookID].DisabledAt == nil && !e.dueAt.After(t) {
+			e.dueAt = t.Add(lease)
+			deliveries = append(deliveries, cloneDelivery(*d))
+		}
+		waiting[key] = true
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, id int, attempt models.DeliveryAttempt, disableAfter int) (*models.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := sort.Search(len(r.deliveries), func(i int) bool { return r.deliveries[i].delivery.ID >= id })
+	if i == len(r.deliveries) || r.deliveries[i].delivery.ID != id {
+		return nil, errs.NotFound("delivery %d not found", id)
+	}
+	e := &r.deliveries[i]
+	d := &e.delivery
+	w := r.webhooks[d.WebhookID]
+
+	t := now()
+	d.Attempts++
+	d.ResponseStatus = attempt.ResponseStatus
+	d.LastError = attempt.Error
+	d.NextAttemptAt = nil
+	switch {
+	case attempt.Error == "":
+		d.Status = models.DeliverySucceeded
+		d.DeliveredAt = &t
+		w.FailureCount = 0
+	case attempt.RetryAt != nil:
+		retryAt := *attempt.RetryAt
+		e.dueAt = retryAt
+		d.NextAttemptAt = &retryAt
+		w.FailureCount++
+	default:
+		d.Status = models.DeliveryFailed
+		w.FailureCount++
+	}
+	if disableAfter > 0 && w.FailureCount >= disableAfter && w.DisabledAt == nil {
+		w.DisabledAt = &t
+	}
+	w.UpdatedAt = t
+	r.webhooks[w.ID] = w
+	return cloneWebhook(w), nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID, limit int) ([]*models.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[webhookID]; !ok {
+		return nil, errs.NotFound("webhook %d not found", webhookID)
+	}
+	var deliveries []*models.WebhookDelivery
+	for i := len(r.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if d := r.deliveries[i].delivery; d.WebhookID == webhookID {
+			deliveries = append(deliveries, cloneDelivery(d))
+		}
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,236 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/lib/pq"
+)
+
+var _ repository.AuthorRepository = (*AuthorRepository)(nil)
+
+type AuthorRepository struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewAuthorRepository(db *sql.DB) *AuthorRepository {
+	return &AuthorRepository{DB: db, Logger: slog.Default()}
+}
+
+const authorColumns = `id, name, created_at, updated_at`
+
+func authorFields(author *models.Author) []any {
+	return []any{&author.ID, &author.Name, &author.CreatedAt, &author.UpdatedAt}
+}
+
+func (r *AuthorRepository) Create(ctx context.Context, author *models.Author) error {
+	err := r.DB.QueryRowContext(ctx, `INSERT INTO authors (name, name_key) VALUES ($1, $2)
+		RETURNING id, created_at, updated_at`,
+		author.Name, models.AuthorKey(author.Name),
+	).Scan(&author.ID, &author.CreatedAt, &author.UpdatedAt)
+	return r.translateError(ctx, err)
+}
+
+func (r *AuthorRepository) List(ctx context.Context, query models.ListAuthorsQuery) (*models.AuthorPage, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if query.NameContains != "" {
+		where = append(where, "name ILIKE "+arg("%"+likeEscaper.Replace(query.NameContains)+"%"))
+	}
+	if query.PageToken != "" {
+		c, err := repository.DecodeAuthorCursor(query.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, `(name COLLATE "C", id) > (`+arg(c.Value)+", "+arg(c.ID)+")")
+	}
+
+	stmt := `SELECT ` + authorColumns + ` FROM authors`
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+	// Fetch one extra row to learn whether another page follows.
+	stmt += ` ORDER BY name COLLATE "C", id LIMIT ` + arg(query.Limit+1)
+
+	rows, err := r.DB.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer rows.Close()
+
+	var authors []*models.Author
+	for rows.Next() {
+		var author models.Author
+		if err := rows.Scan(authorFields(&author)...); err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		authors = append(authors, &author)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+
+	page := &models.AuthorPage{Authors: authors}
+	if len(authors) > query.Limit {
+		page.Authors = authors[:query.Limit]
+		page.NextPageToken = repository.NewAuthorCursor(query, page.Authors[query.Limit-1]).Encode()
+	}
+	return page, nil
+}
+
+func (r *AuthorRepository) FindByID(ctx context.Context, id int) (*models.Author, error) {
+	var author models.Author
+	err := r.DB.QueryRowContext(ctx, `SELECT `+authorColumns+` FROM authors WHERE id = $1`, id).Scan(authorFields(&author)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFound("author %d not found", id)
+	}
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return &author, nil
+}
+
+// refreshAuthorLines recomputes the author line of the books $1, the same
+// way as repository.AuthorLine, and moves them to a new version. Books in
+// the trash are rewritten too, on purpose, so that a restored book carries
+// its authors' current names.
+const refreshAuthorLines = `UPDATE books b SET author = (
+		SELECT COALESCE(
+			string_agg(a.name, ', ' ORDER BY ba.position) FILTER (WHERE ba.role = 'author'),
+			string_agg(a.name, ', ' ORDER BY ba.position))
+		FROM book_authors ba JOIN authors a ON a.id = ba.author_id
+		WHERE ba.book_id = b.id),
+		version = b.version + 1, updated_at = now()
+	WHERE b.id = ANY ($1)
+	RETURNING ` + bookColumns
+
+// Update renames an author and rewrites the author line of their books,
+// recording the change for those that aren't in the trash. The books are
+// locked before the author row, the order in which book writers take them,
+// so that a rename can't deadlock with a book update.
+func (r *AuthorRepository) Update(ctx context.Context, author *models.Author) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockLinkedBooks(ctx, tx, author.ID)
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	err = tx.QueryRowContext(ctx, `UPDATE authors SET name = $1, name_key = $2, updated_at = now()
+		WHERE id = $3
+		RETURNING created_at, updated_at`,
+		author.Name, models.AuthorKey(author.Name), author.ID,
+	).Scan(&author.CreatedAt, &author.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFound("author %d not found", author.ID)
+	}
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	after, err := refreshBooks(ctx, tx, before)
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	for i := range after {
+		if before[i].DeletedAt != nil {
+			continue // subscribers were told it was deleted
+		}
+		if err := recordChange(ctx, tx, models.OpUpdate, before[i], after[i]); err != nil {
+			return r.translateError(ctx, err)
+		}
+	}
+	return r.translateError(ctx, tx.Commit())
+}
+
+// refreshBooks runs refreshAuthorLines over books, which must be locked,
+// returning them as updated in the same order.
+func refreshBooks(ctx context.Context, tx *sql.Tx, books []*models.Book) ([]*models.Book, error) {
+	ids := make(pq.Int64Array, len(books))
+	for i, book := range books {
+		ids[i] = int64(book.ID)
+	}
+	rows, err := tx.QueryContext(ctx, refreshAuthorLines, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[int]*models.Book, len(books))
+	for rows.Next() {
+		var book models.Book
+		if err := rows.Scan(bookFields(&book)...); err != nil {
+			return nil, err
+		}
+		byID[book.ID] = &book
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	updated := make([]*models.Book, len(books))
+	for i, book := range books {
+		updated[i] = byID[book.ID]
+	}
+	return updated, loadContributors(ctx, tx, updated...)
+}
+
+// lockLinkedBooks loads the books linked to an author, in id order, and locks
+// their rows until the transaction ends.
+func lockLinkedBooks(ctx context.Context, tx *sql.Tx, authorID int) ([]*models.Book, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT `+bookColumns+` FROM books
+		WHERE id IN (SELECT book_id FROM book_authors WHERE author_id = $1)
+		ORDER BY id FOR UPDATE`, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []*models.Book
+	for rows.Next() {
+		var book models.Book
+		if err := rows.Scan(bookFields(&book)...); err != nil {
+			return nil, err
+		}
+		books = append(books, &book)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return books, loadContributors(ctx, tx, books...)
+}
+
+func (r *AuthorRepository) Delete(ctx context.Context, id int) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM authors WHERE id = $1`, id)
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	if n == 0 {
+		return errs.NotFound("author %d not found", id)
+	}
+	return nil
+}
+
+func (r *AuthorRepository) translateError(ctx context.Context, err error) error {
+	return logAndTranslate(ctx, r.Logger, err)
+}
@@ -1,57 +1,414 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
 	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/lib/pq"
 )
 
+var _ repository.BookRepository = (*BookRepository)(nil)
+
 type BookRepository struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Logger *slog.Logger
 }
 
 func NewBookRepository(db *sql.DB) *BookRepository {
-	return &BookRepository{DB: db}
+	return &BookRepository{DB: db, Logger: slog.Default()}
 }
 
-func (r *BookRepository) AddBook(book *models.Book) error {
-	query := `INSERT INTO books (title, author, year) VALUES ($1, $2, $3) RETURNING id`
-	return r.DB.QueryRow(query, book.Title, book.Author, book.BookYear).Scan(&book.ID)
+// bookColumns is the select list of every query returning books, in the
+// order bookFields scans them.
+const bookColumns = `id, title, author, year, COALESCE(isbn, ''), publisher, language, pages, description, edition, created_at, updated_at, version, deleted_at`
+
+// bookFields returns scan destinations for bookColumns.
+func bookFields(book *models.Book) []any {
+	return []any{
+		&book.ID, &book.Title, &book.Author, &book.BookYear,
+		&book.ISBN, &book.Publisher, &book.Language, &book.Pages,
+		&book.Description, &book.Edition, &book.CreatedAt, &book.UpdatedAt, &book.Version, &book.DeletedAt,
+	}
 }
 
-func (r *BookRepository) GetBooks() ([]*models.Book, error) {
-	rows, err := r.DB.Query(`SELECT id, title, author, year FROM books`)
+func (r *BookRepository) Create(ctx context.Context, book *models.Book) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := r.prepareContributors(ctx, tx, book); err != nil {
+			return err
+		}
+		query := `INSERT INTO books (title, author, year, isbn, publisher, language, pages, description, edition)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+			RETURNING id, created_at, updated_at, version`
+		err := tx.QueryRowContext(ctx, query,
+			book.Title, book.Author, book.BookYear, book.ISBN, book.Publisher, book.Language, book.Pages, book.Description, book.Edition,
+		).Scan(&book.ID, &book.CreatedAt, &book.UpdatedAt, &book.Version)
+		if err != nil {
+			return err
+		}
+		if err := linkContributors(ctx, tx, book); err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, models.OpCreate, nil, book)
+	})
+}
+
+// prepareContributors resolves the contributors of book and derives its
+// author line from them.
+func (r *BookRepository) prepareContributors(ctx context.Context, tx *sql.Tx, book *models.Book) error {
+	contributors, err := resolveContributors(ctx, tx, repository.BookContributors(book))
 	if err != nil {
-		return nil, err
+		return err
+	}
+	book.Contributors = contributors
+	book.Author = repository.AuthorLine(contributors)
+	return nil
+}
+
+// inTx runs fn in a transaction, committing if it succeeds, and translates
+// whatever error results.
+func (r *BookRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return r.translateError(ctx, err)
+	}
+	return r.translateError(ctx, tx.Commit())
+}
+
+// sortColumns maps sort fields onto SQL expressions. Text is compared
+// byte-wise so that ordering and keyset predicates don't depend on the
+// database locale.
+var sortColumns = map[models.SortField]string{
+	models.SortByID:     "id",
+	models.SortByTitle:  `title COLLATE "C"`,
+	models.SortByAuthor: `author COLLATE "C"`,
+	models.SortByYear:   "year",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *BookRepository) List(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	f := query.Filter
+	if f.Deleted {
+		where = append(where, "deleted_at IS NOT NULL")
+	} else {
+		where = append(where, "deleted_at IS NULL")
+	}
+	if f.Author != "" {
+		where = append(where, `EXISTS (SELECT 1 FROM book_authors ba JOIN authors a ON a.id = ba.author_id
+			WHERE ba.book_id = books.id AND a.name_key = `+arg(models.AuthorKey(f.Author))+`)`)
+	}
+	if f.AuthorID != 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM book_authors ba WHERE ba.book_id = books.id AND ba.author_id = "+arg(f.AuthorID)+")")
+	}
+	if f.YearFrom != 0 {
+		where = append(where, "year >= "+arg(f.YearFrom))
+	}
+	if f.YearTo != 0 {
+		where = append(where, "year <= "+arg(f.YearTo))
+	}
+	if f.TitleContains != "" {
+		where = append(where, "title ILIKE "+arg("%"+likeEscaper.Replace(f.TitleContains)+"%"))
+	}
+	if f.ISBN != "" {
+		where = append(where, "isbn = "+arg(f.ISBN))
+	}
+
+	column := sortColumns[query.SortBy]
+	dir, cmp := "ASC", ">"
+	if query.Desc {
+		dir, cmp = "DESC", "<"
+	}
+
+	if query.PageToken != "" {
+		c, err := repository.DecodeCursor(query.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		switch query.SortBy {
+		case models.SortByID:
+			where = append(where, "id "+cmp+" "+arg(c.ID))
+		case models.SortByYear:
+			year, _ := strconv.Atoi(c.Value)
+			where = append(where, fmt.Sprintf("(year, id) %s (%s, %s)", cmp, arg(year), arg(c.ID)))
+		default:
+			where = append(where, fmt.Sprintf("(%s, id) %s (%s, %s)", column, cmp, arg(c.Value), arg(c.ID)))
+		}
+	}
+
+	stmt := `SELECT ` + bookColumns + ` FROM books WHERE ` + strings.Join(where, " AND ")
+	if query.SortBy == models.SortByID {
+		stmt += " ORDER BY id " + dir
+	} else {
+		stmt += fmt.Sprintf(" ORDER BY %s %s, id %s", column, dir, dir)
+	}
+	// Fetch one extra row to learn whether another page follows.
+	stmt += " LIMIT " + arg(query.Limit+1)
+
+	rows, err := r.DB.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
 	}
 	defer rows.Close()
 
 	var books []*models.Book
 	for rows.Next() {
 		var book models.Book
-		if err := rows.Scan(&book.ID, &book.Title, &book.Author, &book.BookYear); err != nil {
-			return nil, err
+		if err := rows.Scan(bookFields(&book)...); err != nil {
+			return nil, r.translateError(ctx, err)
 		}
 		books = append(books, &book)
 	}
-	return books, nil
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	if err := loadContributors(ctx, r.DB, books...); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+
+	page := &models.BookPage{Books: books}
+	if len(books) > query.Limit {
+		page.Books = books[:query.Limit]
+		page.NextPageToken = repository.NewCursor(query, page.Books[query.Limit-1]).Encode()
+	}
+	return page, nil
 }
 
-func (r *BookRepository) GetBookByID(id int) (*models.Book, error) {
+func (r *BookRepository) FindByID(ctx context.Context, id int) (*models.Book, error) {
 	var book models.Book
-	err := r.DB.QueryRow(`SELECT id, title, author, year FROM books WHERE id = $1`, id).Scan(&book.ID, &book.Title, &book.Author, &book.BookYear)
+	err := r.DB.QueryRowContext(ctx, `SELECT `+bookColumns+` FROM books WHERE id = $1 AND deleted_at IS NULL`, id).Scan(bookFields(&book)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFound("book %d not found", id)
+	}
+	if err == nil {
+		err = loadContributors(ctx, r.DB, &book)
+	}
 	if err != nil {
-		return nil, err
+		return nil, r.translateError(ctx, err)
 	}
 	return &book, nil
 }
 
-func (r *BookRepository) UpdateBook(book *models.Book) error {
-	_, err := r.DB.Exec(`UPDATE books SET title = $1, author = $2, year = $3 WHERE id = $4`, book.Title, book.Author, book.BookYear, book.ID)
-	return err
+func (r *BookRepository) Update(ctx context.Context, book *models.Book) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := lockBook(ctx, tx, book.ID, false)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(before, book.Version); err != nil {
+			return err
+		}
+		if err := r.prepareContributors(ctx, tx, book); err != nil {
+			return err
+		}
+		query := `UPDATE books SET title = $1, author = $2, year = $3, isbn = NULLIF($4, ''), publisher = $5,
+				language = $6, pages = $7, description = $8, edition = $9, updated_at = now(), version = version + 1
+			WHERE id = $10
+			RETURNING created_at, updated_at, version`
+		err = tx.QueryRowContext(ctx, query,
+			book.Title, book.Author, book.BookYear, book.ISBN, book.Publisher, book.Language, book.Pages, book.Description, book.Edition,
+			book.ID,
+		).Scan(&book.CreatedAt, &book.UpdatedAt, &book.Version)
+		if err != nil {
+			return err
+		}
+		if err := linkContributors(ctx, tx, book); err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, models.OpUpdate, before, book)
+	})
+}
+
+// fieldColumns maps the scalar book fields onto their column assignments;
+// %s is the placeholder of the field's value.
+var fieldColumns = map[models.BookField]string{
+	models.FieldTitle:       "title = %s",
+	models.FieldYear:        "year = %s",
+	models.FieldISBN:        "isbn = NULLIF(%s, '')",
+	models.FieldPublisher:   "publisher = %s",
+	models.FieldLanguage:    "language = %s",
+	models.FieldPages:       "pages = %s",
+	models.FieldDescription: "description = %s",
+	models.FieldEdition:     "edition = %s",
+}
+
+// UpdateFields writes only the columns of the given fields, so concurrent
+// patches of different fields don't overwrite each other's values.
+func (r *BookRepository) UpdateFields(ctx context.Context, book *models.Book, fields []models.BookField) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := lockBook(ctx, tx, book.ID, false)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(before, book.Version); err != nil {
+			return err
+		}
+
+		var args []any
+		arg := func(v any) string {
+			args = append(args, v)
+			return "$" + strconv.Itoa(len(args))
+		}
+
+		sets := []string{"updated_at = now()", "version = version + 1"}
+		relink := slices.Contains(fields, models.FieldAuthor) || slices.Contains(fields, models.FieldContributors)
+		if relink {
+			if err := r.prepareContributors(ctx, tx, book); err != nil {
+				return err
+			}
+			sets = append(sets, "author = "+arg(book.Author))
+		}
+		for _, f := range fields {
+			if column, ok := fieldColumns[f]; ok {
+				sets = append(sets, fmt.Sprintf(column, arg(book.FieldValue(f))))
+			}
+		}
+
+		query := `UPDATE books SET ` + strings.Join(sets, ", ") + ` WHERE id = ` + arg(book.ID) + ` RETURNING ` + bookColumns
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(bookFields(book)...); err != nil {
+			return err
+		}
+		if relink {
+			err = linkContributors(ctx, tx, book)
+		} else {
+			book.Contributors = before.Contributors
+		}
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, models.OpUpdate, before, book)
+	})
+}
+
+func (r *BookRepository) Delete(ctx context.Context, id, version int) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := lockBook(ctx, tx, id, false)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(before, version); err != nil {
+			return err
+		}
+		deleted := *before
+		err = tx.QueryRowContext(ctx, `UPDATE books SET deleted_at = now(), updated_at = now(), version = version + 1
+			WHERE id = $1
+			RETURNING deleted_at, updated_at, version`, id,
+		).Scan(&deleted.DeletedAt, &deleted.UpdatedAt, &deleted.Version)
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, models.OpDelete, before, &deleted)
+	})
+}
+
+func (r *BookRepository) FindDeleted(ctx context.Context, id int) (*models.Book, error) {
+	var book models.Book
+	err := r.DB.QueryRowContext(ctx, `SELECT `+bookColumns+` FROM books WHERE id = $1 AND deleted_at IS NOT NULL`, id).Scan(bookFields(&book)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.NotInTrash(id)
+	}
+	if err == nil {
+		err = loadContributors(ctx, r.DB, &book)
+	}
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return &book, nil
+}
+
+// Restore takes a book out of the trash. The partial books_isbn_key index
+// rejects it if a live book has taken its ISBN in the meantime.
+func (r *BookRepository) Restore(ctx context.Context, id int) (*models.Book, error) {
+	var book models.Book
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := lockBook(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+		err = tx.QueryRowContext(ctx, `UPDATE books SET deleted_at = NULL, updated_at = now(), version = version + 1
+			WHERE id = $1
+			RETURNING `+bookColumns, id).Scan(bookFields(&book)...)
+		if err != nil {
+			return err
+		}
+		book.Contributors = before.Contributors
+		return recordChange(ctx, tx, models.OpRestore, before, &book)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
 }
 
-func (r *BookRepository) DeleteBook(id int) error {
-	_, err := r.DB.Exec(`DELETE FROM books WHERE id = $1`, id)
-	return err
+// purgeBooks deletes the books $1, their author links going with them
+// through ON DELETE CASCADE, ends their history with a purge by $2 and
+// queues a BookPurged event for each, as recordChange would. Both are
+// numbered in book id order.
+const purgeBooks = `WITH purged AS (
+		DELETE FROM books WHERE id = ANY ($1) RETURNING id, version
+	), history AS (
+		INSERT INTO book_history (book_id, version, operation, actor)
+		SELECT id, version, 'purge', $2 FROM purged ORDER BY id
+		RETURNING book_id, version, actor, changed_at
+	), events AS (
+		SELECT nextval('outbox_id_seq') AS id, * FROM history ORDER BY book_id
+	)
+	INSERT INTO outbox (id, event_type, book_id, payload)
+	SELECT id, 'BookPurged', book_id, jsonb_build_object(
+		'id', id, 'type', 'BookPurged', 'book_id', book_id, 'version', version,
+		'actor', actor, 'occurred_at', changed_at)
+	FROM events`
+
+// Purge deletes the books trashed before deletedBefore for good. Books
+// locked by a concurrent restore are left for the next purge; the rows are
+// locked before the change sequence, as every writer does.
+func (r *BookRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var n int
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var ids pq.Int64Array
+		err := tx.QueryRowContext(ctx, `SELECT COALESCE(array_agg(id), '{}') FROM (
+			SELECT id FROM books WHERE deleted_at < $1 ORDER BY id FOR UPDATE SKIP LOCKED) t`, deletedBefore,
+		).Scan(&ids)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		if err := lockChangeSequence(ctx, tx); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, purgeBooks, ids, audit.Actor(ctx))
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		n = int(affected)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
 }
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/lib/pq"
+)
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// upsertAuthor returns the author with the key of name, creating it if
+// needed. The no-op update makes RETURNING see an existing row.
+const upsertAuthor = `INSERT INTO authors (name, name_key) VALUES ($1, $2)
+	ON CONFLICT (name_key) DO UPDATE SET name_key = EXCLUDED.name_key
+	RETURNING id, name`
+
+// resolveContributors fills in the author ID and stored name of each
+// contributor, creating authors looked up by a name nobody has yet. It must
+// run in the transaction that links them, so that a failure creates nothing,
+// and after an existing book's row is locked: author rows are locked second,
+// as AuthorRepository.Update does.
+func resolveContributors(ctx context.Context, q queryer, contributors []models.Contributor) ([]models.Contributor, error) {
+	type link struct {
+		authorID int
+		role     models.Role
+	}
+	resolved := make([]models.Contributor, len(contributors))
+	seen := map[link]bool{}
+	for i, c := range contributors {
+		var err error
+		if c.AuthorID == 0 {
+			err = q.QueryRowContext(ctx, upsertAuthor, c.Name, models.AuthorKey(c.Name)).Scan(&c.AuthorID, &c.Name)
+		} else {
+			// The share lock waits for a rename in progress, so that the book
+			// gets the author's current name, and holds off new ones until
+			// the link is committed.
+			err = q.QueryRowContext(ctx, `SELECT name FROM authors WHERE id = $1 FOR SHARE`, c.AuthorID).Scan(&c.Name)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, repository.UnknownAuthor(c.AuthorID)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if seen[link{c.AuthorID, c.Role}] {
+			return nil, repository.DuplicateContributor(c)
+		}
+		seen[link{c.AuthorID, c.Role}] = true
+		resolved[i] = c
+	}
+	return resolved, nil
+}
+
+// linkContributors replaces the author links of book with its contributors,
+// keeping their order.
+func linkContributors(ctx context.Context, q queryer, book *models.Book) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM book_authors WHERE book_id = $1`, book.ID); err != nil {
+		return err
+	}
+	for i, c := range book.Contributors {
+		_, err := q.ExecContext(ctx, `INSERT INTO book_authors (book_id, author_id, role, position) VALUES ($1, $2, $3, $4)`,
+			book.ID, c.AuthorID, c.Role, i)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadContributors fills in the contributors of books with one query.
+func loadContributors(ctx context.Context, q queryer, books ...*models.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+	byID := make(map[int]*models.Book, len(books))
+	ids := make([]int64, len(books))
+	for i, book := range books {
+		byID[book.ID] = book
+		ids[i] = int64(book.ID)
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT ba.book_id, ba.author_id, a.name, ba.role
+		FROM book_authors ba JOIN authors a ON a.id = ba.author_id
+		WHERE ba.book_id = ANY($1)
+		ORDER BY ba.book_id, ba.position`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int
+		var c models.Contributor
+		if err := rows.Scan(&bookID, &c.AuthorID, &c.Name, &c.Role); err != nil {
+			return err
+		}
+		book := byID[bookID]
+		book.Contributors = append(book.Contributors, c)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"net"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/lib/pq"
+)
+
+// translateError translates err and logs the driver error at debug level.
+func (r *BookRepository) translateError(ctx context.Context, err error) error {
+	return logAndTranslate(ctx, r.Logger, err)
+}
+
+// logAndTranslate translates err and logs the driver error at debug level,
+// since its details (SQLSTATE, constraint, ...) don't survive translation.
+func logAndTranslate(ctx context.Context, logger *slog.Logger, err error) error {
+	if err == nil || logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return translateError(ctx, err)
+	}
+	attrs := []slog.Attr{slog.String("error", err.Error())}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		attrs = append(attrs, slog.String("sqlstate", string(pqErr.Code)), slog.String("constraint", pqErr.Constraint))
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "database error", attrs...)
+	return translateError(ctx, err)
+}
+
+// translateError maps driver errors onto the domain error set. Once ctx is
+// done its error is returned instead, whatever the driver reported.
+func translateError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "23505" && pqErr.Constraint == "books_isbn_key": // unique_violation
+			return &errs.Error{Kind: errs.KindConflict, Message: "a book with this isbn already exists", Err: err}
+		case pqErr.Code == "23505" && pqErr.Constraint == "authors_name_key_key":
+			return &errs.Error{Kind: errs.KindConflict, Message: "an author with this name already exists", Err: err}
+		case pqErr.Code == "23505" && pqErr.Constraint == "book_authors_pkey":
+			return &errs.Error{Kind: errs.KindValidation, Message: "an author is listed more than once in the same role", Err: err}
+		case pqErr.Code == "23503" && pqErr.Constraint == "book_authors_author_id_fkey": // foreign_key_violation
+			return &errs.Error{Kind: errs.KindConflict, Message: "author still has books", Err: err}
+		case pqErr.Code == "23505":
+			return &errs.Error{Kind: errs.KindConflict, Message: "book already exists", Err: err}
+		case pqErr.Code.Class() == "23": // integrity constraint violation
+			return &errs.Error{Kind: errs.KindValidation, Message: pqErr.Message, Err: err}
+		case pqErr.Code == "40P01", pqErr.Code == "40001": // deadlock_detected, serialization_failure
+			// The transaction was rolled back and can simply be retried.
+			return errs.Unavailable(err, "database transaction conflict, please retry")
+		case pqErr.Code.Class() == "08", pqErr.Code.Class() == "57", pqErr.Code.Class() == "53":
+			// connection exception, operator intervention, insufficient resources
+			return errs.Unavailable(err, "database unavailable")
+		}
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		return errs.Unavailable(err, "database unavailable")
+	}
+	return err
+}
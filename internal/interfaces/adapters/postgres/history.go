@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+// lockBook loads book id, live or in the trash as deleted says, and locks
+// its row until the transaction ends, so that the state a change is based
+// on is the state its history entry records.
+func lockBook(ctx context.Context, tx *sql.Tx, id int, deleted bool) (*models.Book, error) {
+	cond := "deleted_at IS NULL"
+	if deleted {
+		cond = "deleted_at IS NOT NULL"
+	}
+	var book models.Book
+	err := tx.QueryRowContext(ctx, `SELECT `+bookColumns+` FROM books WHERE id = $1 AND `+cond+` FOR UPDATE`, id).Scan(bookFields(&book)...)
+	switch {
+	case errors.Is(err, sql.ErrNoRows) && deleted:
+		return nil, repository.NotInTrash(id)
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.NotFound("book %d not found", id)
+	case err != nil:
+		return nil, err
+	}
+	if err := loadContributors(ctx, tx, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// checkVersion fails unless book is at version, or version is zero.
+func checkVersion(book *models.Book, version int) error {
+	if version != 0 && version != book.Version {
+		return repository.VersionMismatch(book.ID, book.Version)
+	}
+	return nil
+}
+
+// changeSequenceLock is the transaction-level advisory lock that writers
+// hold from drawing a history ID until they commit. It makes history IDs
+// visible in ID order, so that a reader of Changes never sees a later ID
+// before an earlier one commits. Writers take it after their row locks.
+const changeSequenceLock = 0x626f6f6b // "book"
+
+func lockChangeSequence(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, changeSequenceLock)
+	return err
+}
+
+// recordChange appends the change from before to after, which is nil for
+// creates, to the history of the book, and queues its event in the outbox.
+// It must be the transaction's last statement: it takes the change
+// sequence lock.
+func recordChange(ctx context.Context, tx *sql.Tx, op models.BookOperation, before, after *models.Book) error {
+	change := models.BookChange{
+		BookID:    after.ID,
+		Version:   after.Version,
+		Operation: op,
+		Actor:     audit.Actor(ctx),
+		Changes:   models.DiffBooks(before, after),
+	}
+	changesJSON, err := json.Marshal(change.Changes)
+	if err != nil {
+		return err
+	}
+	if change.Changes == nil {
+		changesJSON = []byte("[]")
+	}
+	snapshot, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+	if err := lockChangeSequence(ctx, tx); err != nil {
+		return err
+	}
+	err = tx.QueryRowContext(ctx, `INSERT INTO book_history (book_id, version, operation, actor, changes, book)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, changed_at`,
+		change.BookID, change.Version, change.Operation, change.Actor, changesJSON, snapshot,
+	).Scan(&change.ID, &change.ChangedAt)
+	if err != nil {
+		return err
+	}
+	return queueEvent(ctx, tx, models.NewBookEvent(&change, after))
+}
+
+// queueEvent writes event to the outbox, under the next outbox ID.
+func queueEvent(ctx context.Context, tx *sql.Tx, event *models.BookEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO outbox (id, event_type, book_id, payload)
+		SELECT id, $1, $2, jsonb_set($3::jsonb, '{id}', to_jsonb(id))
+		FROM (SELECT nextval('outbox_id_seq') AS id) n`,
+		event.Type, event.BookID, payload)
+	return err
+}
+
+func (r *BookRepository) History(ctx context.Context, id int) ([]*models.BookChange, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT id, book_id, version, operation, actor, changed_at, changes
+		FROM book_history WHERE book_id = $1 ORDER BY id`, id)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer rows.Close()
+
+	var changes []*models.BookChange
+	for rows.Next() {
+		var (
+			change models.BookChange
+			diff   []byte
+		)
+		if err := rows.Scan(&change.ID, &change.BookID, &change.Version, &change.Operation, &change.Actor, &change.ChangedAt, &diff); err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		if err := json.Unmarshal(diff, &change.Changes); err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		if len(change.Changes) == 0 {
+			change.Changes = nil
+		}
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	if len(changes) == 0 {
+		return nil, errs.NotFound("book %d not found", id)
+	}
+	return changes, nil
+}
+
+func (r *BookRepository) FindAsOf(ctx context.Context, id int, at time.Time) (*models.Book, error) {
+	var snapshot []byte
+	err := r.DB.QueryRowContext(ctx, `SELECT book FROM book_history
+		WHERE book_id = $1 AND changed_at <= $2
+		ORDER BY id DESC LIMIT 1`, id, at).Scan(&snapshot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.NotFoundAsOf(id, at)
+	}
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+
+	var book models.Book
+	if snapshot == nil {
+		return nil, repository.NotFoundAsOf(id, at)
+	}
+	if err := json.Unmarshal(snapshot, &book); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	if book.DeletedAt != nil {
+		return nil, repository.NotFoundAsOf(id, at)
+	}
+	return &book, nil
+}
+
+func (r *BookRepository) Changes(ctx context.Context, afterID, limit int) ([]*models.BookEvent, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT id, book_id, version, operation, actor, changed_at, changes, book
+		FROM book_history WHERE id > $1 ORDER BY id LIMIT $2`, afterID, limit)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer rows.Close()
+
+	var events []*models.BookEvent
+	for rows.Next() {
+		var (
+			change   models.BookChange
+			diff     []byte
+			snapshot []byte
+			book     *models.Book
+		)
+		err := rows.Scan(&change.ID, &change.BookID, &change.Version, &change.Operation, &change.Actor,
+			&change.ChangedAt, &diff, &snapshot)
+		if err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		if err := json.Unmarshal(diff, &change.Changes); err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		if len(change.Changes) == 0 {
+			change.Changes = nil
+		}
+		if snapshot != nil {
+			book = new(models.Book)
+			if err := json.Unmarshal(snapshot, book); err != nil {
+				return nil, r.translateError(ctx, err)
+			}
+		}
+		event := models.NewBookEvent(&change, book)
+		event.ID = change.ID
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return events, nil
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+var _ repository.OutboxRepository = (*OutboxRepository)(nil)
+
+// OutboxRepository serves the outbox table that BookRepository writes to.
+type OutboxRepository struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{DB: db, Logger: slog.Default()}
+}
+
+// claimEvents leases up to $1 due events for $2 seconds. SKIP LOCKED lets
+// concurrent relays claim disjoint batches, and an event waits for every
+// earlier unpublished event of its book.
+const claimEvents = `UPDATE outbox SET next_attempt_at = now() + make_interval(secs => $2)
+	WHERE id IN (
+		SELECT o.id FROM outbox o
+		WHERE o.published_at IS NULL AND o.next_attempt_at <= now()
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox e
+				WHERE e.book_id = o.book_id AND e.published_at IS NULL AND e.id < o.id)
+		ORDER BY o.id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED)
+	RETURNING id, attempts, payload`
+
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*models.BookEvent, error) {
+	rows, err := r.DB.QueryContext(ctx, claimEvents, limit, lease.Seconds())
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer rows.Close()
+
+	var events []*models.BookEvent
+	for rows.Next() {
+		var (
+			event    models.BookEvent
+			id       int
+			attempts int
+			payload  []byte
+		)
+		if err := rows.Scan(&id, &attempts, &payload); err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		event.ID, event.Attempts = id, attempts
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	// RETURNING doesn't keep the subquery's order.
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int) error {
+	return r.update(ctx, id, `UPDATE outbox SET published_at = now() WHERE id = $1`)
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int, reason string, retryAt time.Time) error {
+	return r.update(ctx, id, `UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1`, reason, retryAt)
+}
+
+func (r *OutboxRepository) update(ctx context.Context, id int, stmt string, args ...any) error {
+	res, err := r.DB.ExecContext(ctx, stmt, append([]any{id}, args...)...)
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	if n == 0 {
+		return errs.NotFound("event %d not found", id)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM outbox WHERE published_at < $1`, publishedBefore)
+	if err != nil {
+		return 0, r.translateError(ctx, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, r.translateError(ctx, err)
+	}
+	return int(n), nil
+}
+
+func (r *OutboxRepository) translateError(ctx context.Context, err error) error {
+	return logAndTranslate(ctx, r.Logger, err)
+}
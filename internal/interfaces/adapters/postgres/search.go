@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+)
+
+// similarityThreshold is the pg_trgm word similarity above which a title or
+// author counts as a typo match for the query.
+const similarityThreshold = "0.4"
+
+const headlineOptions = "StartSel=" + models.HighlightStart + ", StopSel=" + models.HighlightStop + ", HighlightAll=true"
+
+// searchQuery ranks books by full-text match on the weighted search column
+// (title A, author B) plus trigram word similarity, which catches typos the
+// prefix tsquery misses. <% uses the trigram indexes with the threshold set
+// for the transaction.
+const searchQuery = `
+SELECT ` + bookColumns + `,
+	ts_rank(b.search, q) + greatest(word_similarity($2, b.title), word_similarity($2, b.author) * 0.4) AS score,
+	ts_headline('simple', b.title, q, $3),
+	ts_headline('simple', b.author, q, $3)
+FROM books b, to_tsquery('simple', $1) q
+WHERE b.deleted_at IS NULL AND (b.search @@ q OR $2 <% b.title OR $2 <% b.author)
+ORDER BY score DESC, b.id
+LIMIT $4`
+
+func (r *BookRepository) Search(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error) {
+	terms := repository.SearchTerms(query.Query)
+	if len(terms) == 0 {
+		return []*models.SearchResult{}, nil
+	}
+	// Every term must match as a word prefix: "tolk hob" -> 'tolk':* & 'hob':*
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = "'" + term + "':*"
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`, similarityThreshold); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+
+	rows, err := tx.QueryContext(ctx, searchQuery, strings.Join(prefixes, " & "), strings.Join(terms, " "), headlineOptions, query.Limit)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer rows.Close()
+
+	results := []*models.SearchResult{}
+	for rows.Next() {
+		var res models.SearchResult
+		var book models.Book
+		if err := rows.Scan(append(bookFields(&book), &res.Score, &res.Highlights.Title, &res.Highlights.Author)...); err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		res.Book = &book
+		results = append(results, &res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	books := make([]*models.Book, len(results))
+	for i, res := range results {
+		books[i] = res.Book
+	}
+	if err := loadContributors(ctx, tx, books...); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return results, r.translateError(ctx, tx.Commit())
+}
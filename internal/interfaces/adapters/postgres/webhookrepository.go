@@ -0,0 +1,287 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/lib/pq"
+)
+
+var _ repository.WebhookRepository = (*WebhookRepository)(nil)
+
+type WebhookRepository struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{DB: db, Logger: slog.Default()}
+}
+
+const webhookColumns = `id, url, event_types, secret, failure_count, disabled_at, created_at, updated_at`
+
+func scanWebhook(row interface{ Scan(...any) error }) (*models.Webhook, error) {
+	var (
+		webhook models.Webhook
+		types   pq.StringArray
+	)
+	err := row.Scan(&webhook.ID, &webhook.URL, &types, &webhook.Secret, &webhook.FailureCount,
+		&webhook.DisabledAt, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	webhook.EventTypes = make([]models.BookEventType, len(types))
+	for i, t := range types {
+		webhook.EventTypes[i] = models.BookEventType(t)
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	types := make([]string, len(webhook.EventTypes))
+	for i, t := range webhook.EventTypes {
+		types[i] = string(t)
+	}
+	err := r.DB.QueryRowContext(ctx, `INSERT INTO webhooks (url, event_types, secret) VALUES ($1, $2, $3)
+		RETURNING id, failure_count, disabled_at, created_at, updated_at`,
+		webhook.URL, pq.Array(types), webhook.Secret,
+	).Scan(&webhook.ID, &webhook.FailureCount, &webhook.DisabledAt, &webhook.CreatedAt, &webhook.UpdatedAt)
+	return r.translateError(ctx, err)
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]*models.Webhook, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer rows.Close()
+
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id int) (*models.Webhook, error) {
+	webhook, err := scanWebhook(r.DB.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFound("webhook %d not found", id)
+	}
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id int) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return r.translateError(ctx, err)
+	}
+	if n == 0 {
+		return errs.NotFound("webhook %d not found", id)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Enable(ctx context.Context, id int) (*models.Webhook, error) {
+	webhook, err := scanWebhook(r.DB.QueryRowContext(ctx, `UPDATE webhooks
+		SET disabled_at = NULL, failure_count = 0, updated_at = now()
+		WHERE id = $1
+		RETURNING `+webhookColumns, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFound("webhook %d not found", id)
+	}
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return webhook, nil
+}
+
+// enqueueDeliveries adds a delivery of event $1 to every enabled webhook
+// that wants its type $2, or wants every type. The unique key makes it
+// idempotent, so an event the relay publishes twice is delivered once.
+const enqueueDeliveries = `INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, book_id, payload)
+	SELECT id, $1, $2, $3, $4 FROM webhooks
+	WHERE disabled_at IS NULL AND (event_types = '{}' OR $2 = ANY (event_types))
+	ORDER BY id
+	ON CONFLICT (webhook_id, event_id) DO NOTHING`
+
+func (r *WebhookRepository) Enqueue(ctx context.Context, event *models.BookEvent) (int, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.DB.ExecContext(ctx, enqueueDeliveries, event.ID, string(event.Type), event.BookID, payload)
+	if err != nil {
+		return 0, r.translateError(ctx, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, r.translateError(ctx, err)
+	}
+	return int(n), nil
+}
+
+const deliveryColumns = `id, webhook_id, event_id, event_type, book_id, status, attempts,
+	response_status, last_error, payload, next_attempt_at, created_at, delivered_at`
+
+func scanDelivery(row interface{ Scan(...any) error }) (*models.WebhookDelivery, error) {
+	var (
+		d              models.WebhookDelivery
+		responseStatus sql.NullInt64
+		lastError      sql.NullString
+		nextAttemptAt  time.Time
+	)
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.BookID, &d.Status, &d.Attempts,
+		&responseStatus, &lastError, &d.Payload, &nextAttemptAt, &d.CreatedAt, &d.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	d.ResponseStatus = int(responseStatus.Int64)
+	d.LastError = lastError.String
+	if d.Status == models.DeliveryPending {
+		d.NextAttemptAt = &nextAttemptAt
+	}
+	return &d, nil
+}
+
+// claimDeliveries leases up to $1 due deliveries to enabled webhooks for $2
+// seconds. As with the outbox, a delivery waits for every earlier pending
+// delivery of its book to the same webhook.
+const claimDeliveries = `UPDATE webhook_deliveries SET next_attempt_at = now() + make_interval(secs => $2)
+	WHERE id IN (
+		SELECT d.id FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id AND w.disabled_at IS NULL
+		WHERE d.status = 'pending' AND d.next_attempt_at <= now()
+			AND NOT EXISTS (
+				SELECT 1 FROM webhook_deliveries e
+				WHERE e.webhook_id = d.webhook_id AND e.book_id = d.book_id
+					AND e.status = 'pending' AND e.id < d.id)
+		ORDER BY d.id
+		LIMIT $1
+		FOR UPDATE OF d SKIP LOCKED)
+	RETURNING ` + deliveryColumns
+
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	deliveries, err := r.queryDeliveries(ctx, claimDeliveries, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING doesn't keep the subquery's order.
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries, nil
+}
+
+// recordAttempt settles delivery $1 when the attempt succeeded ($3 IS NULL)
+// or gave up ($4 IS NULL), and otherwise makes it due again at $4.
+const recordAttempt = `UPDATE webhook_deliveries SET
+		attempts = attempts + 1,
+		response_status = NULLIF($2, 0),
+		last_error = $3,
+		status = CASE WHEN $3::text IS NULL THEN 'succeeded' WHEN $4::timestamptz IS NULL THEN 'failed' ELSE 'pending' END,
+		next_attempt_at = COALESCE($4, next_attempt_at),
+		delivered_at = CASE WHEN $3::text IS NULL THEN now() END
+	WHERE id = $1
+	RETURNING webhook_id`
+
+// countAttempt resets the failure count of webhook $1 after a success, or
+// counts a failure and disables the webhook once it reaches $3.
+const countAttempt = `UPDATE webhooks SET
+		failure_count = CASE WHEN $2 THEN failure_count + 1 ELSE 0 END,
+		disabled_at = CASE
+			WHEN disabled_at IS NULL AND $2 AND $3 > 0 AND failure_count + 1 >= $3 THEN now()
+			ELSE disabled_at END,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING ` + webhookColumns
+
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, id int, attempt models.DeliveryAttempt, disableAfter int) (*models.Webhook, error) {
+	var lastError *string
+	if attempt.Error != "" {
+		lastError = &attempt.Error
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer tx.Rollback()
+
+	var webhookID int
+	err = tx.QueryRowContext(ctx, recordAttempt, id, attempt.ResponseStatus, lastError, attempt.RetryAt).Scan(&webhookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFound("delivery %d not found", id)
+	}
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	webhook, err := scanWebhook(tx.QueryRowContext(ctx, countAttempt, webhookID, lastError != nil, disableAfter))
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID, limit int) ([]*models.WebhookDelivery, error) {
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = $1)`, webhookID).Scan(&exists)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	if !exists {
+		return nil, errs.NotFound("webhook %d not found", webhookID)
+	}
+	return r.queryDeliveries(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY id DESC
+		LIMIT $2`, webhookID, limit)
+}
+
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, stmt string, args ...any) ([]*models.WebhookDelivery, error) {
+	rows, err := r.DB.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, r.translateError(ctx, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.translateError(ctx, err)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) translateError(ctx context.Context, err error) error {
+	return logAndTranslate(ctx, r.Logger, err)
+}
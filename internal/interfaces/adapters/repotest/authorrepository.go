@@ -0,0 +1,389 @@
+package repotest
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AuthorRepositoryFactory returns empty book and author repositories that
+// share storage, for a single subtest.
+type AuthorRepositoryFactory func(t *testing.T) (repository.BookRepository, repository.AuthorRepository)
+
+// RunAuthorRepositoryContract runs the conformance suite for authors and the
+// contributors linking them to books against the adapters produced by newRepos.
+func RunAuthorRepositoryContract(t *testing.T, newRepos AuthorRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository)
+	}{
+		{"CreateRejectsSameKey", testCreateAuthorRejectsSameKey},
+		{"FindByIDNotFound", testFindAuthorNotFound},
+		{"BooksShareAuthorsByKey", testBooksShareAuthorsByKey},
+		{"ContributorsKeepOrderAndRoles", testContributorsKeepOrderAndRoles},
+		{"UnknownAuthorCreatesNothing", testUnknownAuthorCreatesNothing},
+		{"DuplicateContributorRejected", testDuplicateContributorRejected},
+		{"ListPaginatesByName", testListAuthorsPaginatesByName},
+		{"ListFiltersByName", testListAuthorsFiltersByName},
+		{"RenameRefreshesBooks", testRenameRefreshesBooks},
+		{"RenameRejectsSameKey", testRenameRejectsSameKey},
+		{"RenameRecordsLiveBooksInOrder", testRenameRecordsLiveBooksInOrder},
+		{"RenameRacesBookUpdates", testRenameRacesBookUpdates},
+		{"DeleteWhileLinkedConflicts", testDeleteLinkedAuthorConflicts},
+		{"ListBooksByAuthor", testListBooksByAuthor},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			books, authors := newRepos(t)
+			tc.run(t, books, authors)
+		})
+	}
+}
+
+func mustCreateAuthor(t *testing.T, repo repository.AuthorRepository, name string) *models.Author {
+	t.Helper()
+	author := &models.Author{Name: name}
+	require.NoError(t, repo.Create(ctx, author))
+	return author
+}
+
+func listAllAuthors(t *testing.T, repo repository.AuthorRepository, query models.ListAuthorsQuery) []*models.Author {
+	t.Helper()
+	if query.Limit == 0 {
+		query.Limit = 100
+	}
+
+	var authors []*models.Author
+	for {
+		page, err := repo.List(ctx, query)
+		require.NoError(t, err)
+		authors = append(authors, page.Authors...)
+		if page.NextPageToken == "" {
+			return authors
+		}
+		require.Len(t, page.Authors, query.Limit, "only the last page may be short")
+		query.PageToken = page.NextPageToken
+	}
+}
+
+func names(authors []*models.Author) []string {
+	out := make([]string, len(authors))
+	for i, author := range authors {
+		out[i] = author.Name
+	}
+	return out
+}
+
+func testCreateAuthorRejectsSameKey(t *testing.T, _ repository.BookRepository, authors repository.AuthorRepository) {
+	created := mustCreateAuthor(t, authors, "J. R. R. Tolkien")
+	assert.Positive(t, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	err := authors.Create(ctx, &models.Author{Name: "jrr tolkien"})
+	assert.True(t, errors.Is(err, errs.ErrConflict), "expected conflict, got %v", err)
+}
+
+func testFindAuthorNotFound(t *testing.T, _ repository.BookRepository, authors repository.AuthorRepository) {
+	got, err := authors.FindByID(ctx, 999999)
+	assert.Nil(t, got)
+	assertNotFound(t, err)
+}
+
+func testBooksShareAuthorsByKey(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	hobbit := mustCreate(t, books, &models.Book{Title: "The Hobbit", Author: "J. R. R. Tolkien", BookYear: 1937})
+	silmarillion := mustCreate(t, books, &models.Book{Title: "The Silmarillion", Author: "JRR Tolkien", BookYear: 1977})
+
+	require.Len(t, hobbit.Contributors, 1)
+	require.Len(t, silmarillion.Contributors, 1)
+	id := hobbit.Contributors[0].AuthorID
+	assert.Positive(t, id)
+	assert.Equal(t, id, silmarillion.Contributors[0].AuthorID)
+	assert.Equal(t, models.RoleAuthor, silmarillion.Contributors[0].Role)
+	assert.Equal(t, "J. R. R. Tolkien", silmarillion.Author, "the author line uses the stored name")
+
+	author, err := authors.FindByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "J. R. R. Tolkien", author.Name)
+}
+
+func testContributorsKeepOrderAndRoles(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	translator := mustCreateAuthor(t, authors, "Edith Grossman")
+	book := mustCreate(t, books, &models.Book{
+		Title:    "Don Quixote",
+		BookYear: 1605,
+		Contributors: []models.Contributor{
+			{AuthorID: translator.ID, Role: models.RoleTranslator},
+			{Name: "Miguel de Cervantes"},
+			{Name: "Harold Bloom", Role: models.RoleEditor},
+		},
+	})
+
+	assert.Equal(t, "Miguel de Cervantes", book.Author, "only authors make up the author line")
+	got, err := books.FindByID(ctx, book.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Contributors, 3)
+	assert.Equal(t, models.Contributor{AuthorID: translator.ID, Name: "Edith Grossman", Role: models.RoleTranslator}, got.Contributors[0])
+	assert.Equal(t, "Miguel de Cervantes", got.Contributors[1].Name)
+	assert.Equal(t, models.RoleAuthor, got.Contributors[1].Role)
+	assert.Equal(t, models.RoleEditor, got.Contributors[2].Role)
+
+	got.Contributors = got.Contributors[:1]
+	require.NoError(t, books.Update(ctx, got))
+	assert.Equal(t, "Edith Grossman", got.Author, "without authors every contributor makes up the line")
+	again, err := books.FindByID(ctx, book.ID)
+	require.NoError(t, err)
+	assert.Len(t, again.Contributors, 1)
+}
+
+func testUnknownAuthorCreatesNothing(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	err := books.Create(ctx, &models.Book{
+		Title:    "Orphan",
+		BookYear: 2000,
+		Contributors: []models.Contributor{
+			{Name: "Brand New Author", Role: models.RoleAuthor},
+			{AuthorID: 999999, Role: models.RoleEditor},
+		},
+	})
+	assert.True(t, errors.Is(err, errs.ErrValidation), "expected validation error, got %v", err)
+
+	assert.Empty(t, listAllAuthors(t, authors, models.ListAuthorsQuery{}))
+	assert.Empty(t, listAll(t, books, models.ListBooksQuery{}))
+}
+
+func testDuplicateContributorRejected(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	err := books.Create(ctx, &models.Book{
+		Title:    "Twice",
+		BookYear: 2000,
+		Contributors: []models.Contributor{
+			{Name: "Ursula K. Le Guin", Role: models.RoleAuthor},
+			{Name: "Ursula K Le Guin", Role: models.RoleAuthor},
+		},
+	})
+	assert.True(t, errors.Is(err, errs.ErrValidation), "expected validation error, got %v", err)
+	assert.Empty(t, listAllAuthors(t, authors, models.ListAuthorsQuery{}))
+
+	mustCreate(t, books, &models.Book{
+		Title:    "Both roles",
+		BookYear: 2000,
+		Contributors: []models.Contributor{
+			{Name: "Ursula K. Le Guin", Role: models.RoleAuthor},
+			{Name: "Ursula K. Le Guin", Role: models.RoleTranslator},
+		},
+	})
+}
+
+func testListAuthorsPaginatesByName(t *testing.T, _ repository.BookRepository, authors repository.AuthorRepository) {
+	for _, name := range []string{"Zadie Smith", "Alice Munro", "Mary Shelley", "Chinua Achebe", "Kazuo Ishiguro"} {
+		mustCreateAuthor(t, authors, name)
+	}
+
+	got := listAllAuthors(t, authors, models.ListAuthorsQuery{Limit: 2})
+	assert.Equal(t, []string{"Alice Munro", "Chinua Achebe", "Kazuo Ishiguro", "Mary Shelley", "Zadie Smith"}, names(got))
+
+	_, err := authors.List(ctx, models.ListAuthorsQuery{Limit: 2, PageToken: "not-a-token"})
+	assert.True(t, errors.Is(err, errs.ErrValidation), "expected validation error, got %v", err)
+
+	page, err := authors.List(ctx, models.ListAuthorsQuery{Limit: 2})
+	require.NoError(t, err)
+	_, err = authors.List(ctx, models.ListAuthorsQuery{NameContains: "a", Limit: 2, PageToken: page.NextPageToken})
+	assert.True(t, errors.Is(err, errs.ErrValidation), "tokens are bound to their filter, got %v", err)
+}
+
+func testListAuthorsFiltersByName(t *testing.T, _ repository.BookRepository, authors repository.AuthorRepository) {
+	mustCreateAuthor(t, authors, "Mary Shelley")
+	mustCreateAuthor(t, authors, "Percy Bysshe Shelley")
+	mustCreateAuthor(t, authors, "Mary_Beard")
+
+	got := listAllAuthors(t, authors, models.ListAuthorsQuery{NameContains: "SHELL"})
+	assert.Equal(t, []string{"Mary Shelley", "Percy Bysshe Shelley"}, names(got))
+	got = listAllAuthors(t, authors, models.ListAuthorsQuery{NameContains: "_"})
+	assert.Equal(t, []string{"Mary_Beard"}, names(got), "wildcards are matched literally")
+}
+
+func testRenameRefreshesBooks(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	book := mustCreate(t, books, &models.Book{
+		Title:    "Good Omens",
+		BookYear: 1990,
+		Contributors: []models.Contributor{
+			{Name: "Terry Pratchett"},
+			{Name: "Neil Gaiman"},
+		},
+	})
+	assert.Equal(t, "Terry Pratchett, Neil Gaiman", book.Author)
+
+	renamed := &models.Author{ID: book.Contributors[1].AuthorID, Name: "Neil Richard Gaiman"}
+	require.NoError(t, authors.Update(ctx, renamed))
+	assert.False(t, renamed.UpdatedAt.Before(renamed.CreatedAt))
+
+	got, err := books.FindByID(ctx, book.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Terry Pratchett, Neil Richard Gaiman", got.Author)
+	assert.Equal(t, book.Version+1, got.Version, "renames change the book's version")
+	assert.True(t, got.UpdatedAt.Equal(renamed.UpdatedAt), "renames touch the book's updated_at")
+	assert.Equal(t, "Neil Richard Gaiman", got.Contributors[1].Name)
+
+	results, err := books.Search(ctx, models.SearchQuery{Query: "richard", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1, "renames reach the search index")
+
+	changes, err := books.History(ctx, book.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2, "renames are recorded in the book's history")
+	assert.Equal(t, models.OpUpdate, changes[1].Operation)
+	assert.Equal(t, got.Version, changes[1].Version)
+	var fields []models.BookField
+	for _, change := range changes[1].Changes {
+		fields = append(fields, change.Field)
+	}
+	assert.Contains(t, fields, models.FieldAuthor)
+
+	err = authors.Update(ctx, &models.Author{ID: 999999, Name: "Nobody"})
+	assertNotFound(t, err)
+}
+
+func testRenameRecordsLiveBooksInOrder(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	var linked []*models.Book
+	for _, title := range []string{"A Wizard of Earthsea", "The Tombs of Atuan", "The Farthest Shore"} {
+		linked = append(linked, mustCreate(t, books, &models.Book{
+			Title:        title,
+			BookYear:     1970,
+			Contributors: []models.Contributor{{Name: "Ursula Le Guin"}},
+		}))
+	}
+	trashed := linked[1]
+	require.NoError(t, books.Delete(ctx, trashed.ID, trashed.Version))
+	seen, err := books.Changes(ctx, 0, 100)
+	require.NoError(t, err)
+	last := seen[len(seen)-1].ID
+
+	renamed := &models.Author{ID: linked[0].Contributors[0].AuthorID, Name: "Ursula K. Le Guin"}
+	require.NoError(t, authors.Update(ctx, renamed))
+
+	events, err := books.Changes(ctx, last, 100)
+	require.NoError(t, err)
+	require.Len(t, events, 2, "books in the trash get no events")
+	for i, book := range []*models.Book{linked[0], linked[2]} {
+		assert.Equal(t, models.BookUpdated, events[i].Type)
+		assert.Equal(t, book.ID, events[i].BookID, "changes are recorded in book id order")
+	}
+	changes, err := books.History(ctx, trashed.ID)
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+
+	restored, err := books.Restore(ctx, trashed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ursula K. Le Guin", restored.Author, "trashed books are rewritten all the same")
+}
+
+func testRenameRacesBookUpdates(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	var linked []*models.Book
+	for _, title := range []string{"Kindred", "Dawn", "Parable of the Sower"} {
+		linked = append(linked, mustCreate(t, books, &models.Book{
+			Title:        title,
+			BookYear:     1979,
+			Contributors: []models.Contributor{{Name: "Octavia Butler"}},
+		}))
+	}
+	authorID := linked[0].Contributors[0].AuthorID
+
+	// Renames lock the author's books and then the author, while book
+	// updates lock the book and then its authors; neither may deadlock or
+	// leave a book with a name from before the last rename.
+	const rounds = 10
+	var wg sync.WaitGroup
+	errCh := make(chan error, rounds*(len(linked)+1))
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			name := []string{"Octavia E. Butler", "Octavia Estelle Butler"}[i%2]
+			errCh <- authors.Update(ctx, &models.Author{ID: authorID, Name: name})
+		}
+	}()
+	for _, book := range linked {
+		wg.Add(1)
+		go func(book models.Book) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				book.Version = 0
+				book.Edition = strconv.Itoa(i)
+				book.Contributors = []models.Contributor{{AuthorID: authorID}}
+				errCh <- books.Update(ctx, &book)
+			}
+		}(*book)
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	author, err := authors.FindByID(ctx, authorID)
+	require.NoError(t, err)
+	assert.Equal(t, "Octavia Estelle Butler", author.Name)
+	for _, book := range linked {
+		got, err := books.FindByID(ctx, book.ID)
+		require.NoError(t, err)
+		assert.Equal(t, author.Name, got.Author, "book %d", book.ID)
+		assert.Equal(t, author.Name, got.Contributors[0].Name, "book %d", book.ID)
+	}
+}
+
+func testRenameRejectsSameKey(t *testing.T, _ repository.BookRepository, authors repository.AuthorRepository) {
+	mustCreateAuthor(t, authors, "Iain Banks")
+	other := mustCreateAuthor(t, authors, "Iain M. Banks")
+
+	err := authors.Update(ctx, &models.Author{ID: other.ID, Name: "IAIN BANKS"})
+	assert.True(t, errors.Is(err, errs.ErrConflict), "expected conflict, got %v", err)
+
+	// Changing only the spelling keeps the key and is allowed.
+	require.NoError(t, authors.Update(ctx, &models.Author{ID: other.ID, Name: "Iain M Banks"}))
+}
+
+func testDeleteLinkedAuthorConflicts(t *testing.T, books repository.BookRepository, authors repository.AuthorRepository) {
+	book := mustCreate(t, books, &models.Book{Title: "Beloved", Author: "Toni Morrison", BookYear: 1987})
+	id := book.Contributors[0].AuthorID
+
+	err := authors.Delete(ctx, id)
+	assert.True(t, errors.Is(err, errs.ErrConflict), "expected conflict, got %v", err)
+
+	require.NoError(t, books.Delete(ctx, book.ID, book.Version))
+	err = authors.Delete(ctx, id)
+	assert.True(t, errors.Is(err, errs.ErrConflict), "books in the trash still link the author, got %v", err)
+
+	_, err = books.Purge(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, authors.Delete(ctx, id))
+	_, err = authors.FindByID(ctx, id)
+	assertNotFound(t, err)
+	assertNotFound(t, authors.Delete(ctx, id))
+}
+
+func testListBooksByAuthor(t *testing.T, books repository.BookRepository, _ repository.AuthorRepository) {
+	sandman := mustCreate(t, books, &models.Book{Title: "Sandman", Author: "Neil Gaiman", BookYear: 1989})
+	mustCreate(t, books, &models.Book{Title: "Discworld", Author: "Terry Pratchett", BookYear: 1983})
+	mustCreate(t, books, &models.Book{
+		Title:    "Norse Mythology Reader",
+		BookYear: 2017,
+		Contributors: []models.Contributor{
+			{Name: "Snorri Sturluson"},
+			{Name: "Neil Gaiman", Role: models.RoleEditor},
+		},
+	})
+
+	id := sandman.Contributors[0].AuthorID
+	got := listAll(t, books, models.ListBooksQuery{Filter: models.BookFilter{AuthorID: id}})
+	assert.Equal(t, []string{"Sandman", "Norse Mythology Reader"}, titles(got))
+
+	got = listAll(t, books, models.ListBooksQuery{Filter: models.BookFilter{Author: "neil gaiman"}})
+	assert.Equal(t, []string{"Sandman", "Norse Mythology Reader"}, titles(got), "the author filter matches any role")
+}
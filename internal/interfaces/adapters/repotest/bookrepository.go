@@ -0,0 +1,753 @@
+// Package repotest holds the conformance suite that every implementation of
+// repository.BookRepository must pass.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ctx is used for every call the suite makes that isn't about cancellation.
+var ctx = context.Background()
+
+// BookRepositoryFactory returns an empty repository for a single subtest.
+type BookRepositoryFactory func(t *testing.T) repository.BookRepository
+
+// RunBookRepositoryContract runs the conformance suite against the adapter
+// produced by newRepo.
+func RunBookRepositoryContract(t *testing.T, newRepo BookRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, repo repository.BookRepository)
+	}{
+		{"CreateAssignsIncreasingIDs", testCreateAssignsIncreasingIDs},
+		{"FindByIDReturnsStoredFields", testFindByIDReturnsStoredFields},
+		{"FindByIDNotFound", testFindByIDNotFound},
+		{"StoresBookDetails", testStoresBookDetails},
+		{"UpdatePersists", testUpdatePersists},
+		{"UpdateKeepsCreatedAt", testUpdateKeepsCreatedAt},
+		{"ISBNIsUnique", testISBNIsUnique},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"VersionsGuardChanges", testVersionsGuardChanges},
+		{"UpdateFieldsWritesOnlyThoseFields", testUpdateFieldsWritesOnlyThoseFields},
+		{"DeleteRemoves", testDeleteRemoves},
+		{"DeleteNotFound", testDeleteNotFound},
+		{"IDsAreNotReused", testIDsAreNotReused},
+		{"DeleteMovesToTrash", testDeleteMovesToTrash},
+		{"RestoreBringsBookBack", testRestoreBringsBookBack},
+		{"RestoreRejectsTakenISBN", testRestoreRejectsTakenISBN},
+		{"PurgeRemovesOldDeletes", testPurgeRemovesOldDeletes},
+		{"PurgeRecordsBooksInIDOrder", testPurgeRecordsBooksInIDOrder},
+		{"HistoryRecordsEveryChange", testHistoryRecordsEveryChange},
+		{"FindAsOfReturnsPastStates", testFindAsOfReturnsPastStates},
+		{"ChangesResumeAfterID", testChangesResumeAfterID},
+		{"ListOrdersByIDByDefault", testListOrdersByIDByDefault},
+		{"ListPaginatesWithoutGaps", testListPaginatesWithoutGaps},
+		{"ListSortsWithIDTieBreak", testListSortsWithIDTieBreak},
+		{"ListSortsByYearDescending", testListSortsByYearDescending},
+		{"ListFilters", testListFilters},
+		{"ListRejectsMismatchedPageToken", testListRejectsMismatchedPageToken},
+		{"SearchRanksTitleAboveAuthor", testSearchRanksTitleAboveAuthor},
+		{"SearchMatchesPrefixesAndTypos", testSearchMatchesPrefixesAndTypos},
+		{"SearchHighlights", testSearchHighlights},
+		{"SearchFollowsUpdatesAndDeletes", testSearchFollowsUpdatesAndDeletes},
+		{"ConcurrentCreates", testConcurrentCreates},
+		{"ObservesCancellation", testObservesCancellation},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newRepo(t))
+		})
+	}
+}
+
+func newBook(title string) *models.Book {
+	return &models.Book{Title: title, Author: "Contract Author", BookYear: 2001}
+}
+
+func mustCreate(t *testing.T, repo repository.BookRepository, book *models.Book) *models.Book {
+	t.Helper()
+	require.NoError(t, repo.Create(ctx, book))
+	return book
+}
+
+// listAll pages through query with the given page size and returns every book.
+func listAll(t *testing.T, repo repository.BookRepository, query models.ListBooksQuery) []*models.Book {
+	t.Helper()
+	if query.SortBy == "" {
+		query.SortBy = models.SortByID
+	}
+	if query.Limit == 0 {
+		query.Limit = 100
+	}
+
+	var books []*models.Book
+	for {
+		page, err := repo.List(ctx, query)
+		require.NoError(t, err)
+		books = append(books, page.Books...)
+		if page.NextPageToken == "" {
+			return books
+		}
+		require.Len(t, page.Books, query.Limit, "only the last page may be short")
+		query.PageToken = page.NextPageToken
+	}
+}
+
+func titles(books []*models.Book) []string {
+	out := make([]string, len(books))
+	for i, book := range books {
+		out[i] = book.Title
+	}
+	return out
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+	assert.True(t, errors.Is(err, errs.ErrNotFound), "expected not-found error, got %v", err)
+}
+
+func testCreateAssignsIncreasingIDs(t *testing.T, repo repository.BookRepository) {
+	first := mustCreate(t, repo, newBook("First"))
+	second := mustCreate(t, repo, newBook("Second"))
+
+	assert.Positive(t, first.ID)
+	assert.Greater(t, second.ID, first.ID)
+}
+
+func testFindByIDReturnsStoredFields(t *testing.T, repo repository.BookRepository) {
+	created := mustCreate(t, repo, &models.Book{Title: "Dune", Author: "Frank Herbert", BookYear: 1965})
+
+	got, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *created, *got)
+
+	got.Title = "mutated"
+	again, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", again.Title, "returned books must not alias stored state")
+}
+
+func testFindByIDNotFound(t *testing.T, repo repository.BookRepository) {
+	got, err := repo.FindByID(ctx, 999999)
+	assert.Nil(t, got)
+	assertNotFound(t, err)
+}
+
+func testUpdatePersists(t *testing.T, repo repository.BookRepository) {
+	created := mustCreate(t, repo, newBook("Before"))
+
+	created.Title = "After"
+	created.BookYear = 2002
+	require.NoError(t, repo.Update(ctx, created))
+
+	got, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "After", got.Title)
+	assert.Equal(t, 2002, got.BookYear)
+}
+
+func testVersionsGuardChanges(t *testing.T, repo repository.BookRepository) {
+	created := mustCreate(t, repo, newBook("Versioned"))
+	assert.Equal(t, 1, created.Version)
+
+	stale := *created
+	created.Title = "First edit"
+	require.NoError(t, repo.Update(ctx, created))
+	assert.Equal(t, 2, created.Version)
+
+	stale.Title = "Lost edit"
+	err := repo.Update(ctx, &stale)
+	assert.True(t, errors.Is(err, errs.ErrPrecondition), "expected precondition error, got %v", err)
+	got, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First edit", got.Title)
+	assert.Equal(t, 2, got.Version)
+
+	err = repo.Delete(ctx, created.ID, 1)
+	assert.True(t, errors.Is(err, errs.ErrPrecondition), "expected precondition error, got %v", err)
+	assertNotFound(t, repo.Delete(ctx, 999999, 1))
+
+	got.Version = 0
+	got.Title = "Unconditional"
+	require.NoError(t, repo.Update(ctx, got))
+	assert.Equal(t, 3, got.Version)
+	require.NoError(t, repo.Delete(ctx, created.ID, 3))
+}
+
+func testUpdateFieldsWritesOnlyThoseFields(t *testing.T, repo repository.BookRepository) {
+	created := mustCreate(t, repo, &models.Book{Title: "Emma", Author: "Jane Austen", BookYear: 1815, Publisher: "John Murray"})
+
+	// Values of fields that aren't listed are ignored, not written.
+	patch := &models.Book{ID: created.ID, Version: created.Version, Title: "ignored", Pages: 474, ISBN: "9780141439587"}
+	require.NoError(t, repo.UpdateFields(ctx, patch, []models.BookField{models.FieldPages, models.FieldISBN}))
+	assert.Equal(t, "Emma", patch.Title, "the whole stored book is returned")
+	assert.Equal(t, "John Murray", patch.Publisher)
+	assert.Equal(t, 474, patch.Pages)
+	assert.Equal(t, created.Version+1, patch.Version)
+	assert.Equal(t, created.Contributors, patch.Contributors)
+
+	got, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *patch, *got)
+
+	patch = &models.Book{ID: created.ID, Version: got.Version, Contributors: []models.Contributor{
+		{Name: "Jane Austen"}, {Name: "Fiona Stafford", Role: models.RoleEditor},
+	}}
+	require.NoError(t, repo.UpdateFields(ctx, patch, []models.BookField{models.FieldContributors}))
+	assert.Equal(t, "Jane Austen", patch.Author)
+	assert.Len(t, patch.Contributors, 2)
+	assert.Equal(t, 474, patch.Pages)
+
+	stale := &models.Book{ID: created.ID, Version: created.Version, Pages: 1}
+	err = repo.UpdateFields(ctx, stale, []models.BookField{models.FieldPages})
+	assert.True(t, errors.Is(err, errs.ErrPrecondition), "expected precondition error, got %v", err)
+	err = repo.UpdateFields(ctx, &models.Book{ID: 999999, Pages: 1}, []models.BookField{models.FieldPages})
+	assertNotFound(t, err)
+}
+
+func testStoresBookDetails(t *testing.T, repo repository.BookRepository) {
+	created := mustCreate(t, repo, &models.Book{
+		Title: "The Hobbit", Author: "J.R.R. Tolkien", BookYear: 1937,
+		ISBN: "9780261102217", Publisher: "HarperCollins", Language: "en-GB",
+		Pages: 310, Description: "There and back again.", Edition: "Reissue",
+	})
+	assert.False(t, created.CreatedAt.IsZero(), "Create sets CreatedAt")
+	assert.True(t, created.CreatedAt.Equal(created.UpdatedAt))
+
+	got, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "9780261102217", got.ISBN)
+	assert.Equal(t, "HarperCollins", got.Publisher)
+	assert.Equal(t, "en-GB", got.Language)
+	assert.Equal(t, 310, got.Pages)
+	assert.Equal(t, "There and back again.", got.Description)
+	assert.Equal(t, "Reissue", got.Edition)
+	assert.True(t, created.CreatedAt.Equal(got.CreatedAt))
+}
+
+func testUpdateKeepsCreatedAt(t *testing.T, repo repository.BookRepository) {
+	created := mustCreate(t, repo, newBook("Timestamps"))
+	createdAt := created.CreatedAt
+
+	update := newBook("Timestamps, revised")
+	update.ID = created.ID
+	update.CreatedAt = createdAt.Add(-time.Hour) // ignored
+	require.NoError(t, repo.Update(ctx, update))
+	assert.True(t, createdAt.Equal(update.CreatedAt), "Update reports the stored CreatedAt")
+	assert.False(t, update.UpdatedAt.Before(createdAt))
+
+	got, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(got.CreatedAt))
+	assert.True(t, update.UpdatedAt.Equal(got.UpdatedAt))
+}
+
+func testISBNIsUnique(t *testing.T, repo repository.BookRepository) {
+	first := newBook("First")
+	first.ISBN = "9780306406157"
+	mustCreate(t, repo, first)
+	mustCreate(t, repo, newBook("No ISBN"))
+	mustCreate(t, repo, newBook("No ISBN either"))
+
+	dup := newBook("Duplicate")
+	dup.ISBN = first.ISBN
+	assert.True(t, errors.Is(repo.Create(ctx, dup), errs.ErrConflict), "creating a duplicate ISBN conflicts")
+
+	other := mustCreate(t, repo, newBook("Other"))
+	other.ISBN = first.ISBN
+	assert.True(t, errors.Is(repo.Update(ctx, other), errs.ErrConflict), "updating to a taken ISBN conflicts")
+
+	first.Title = "First, revised"
+	assert.NoError(t, repo.Update(ctx, first), "a book keeps its own ISBN")
+}
+
+func testUpdateNotFound(t *testing.T, repo repository.BookRepository) {
+	book := newBook("Ghost")
+	book.ID = 999999
+	assertNotFound(t, repo.Update(ctx, book))
+}
+
+func testDeleteRemoves(t *testing.T, repo repository.BookRepository) {
+	created := mustCreate(t, repo, newBook("Doomed"))
+
+	require.NoError(t, repo.Delete(ctx, created.ID, 0))
+	_, err := repo.FindByID(ctx, created.ID)
+	assertNotFound(t, err)
+}
+
+func testDeleteNotFound(t *testing.T, repo repository.BookRepository) {
+	assertNotFound(t, repo.Delete(ctx, 999999, 0))
+}
+
+func testIDsAreNotReused(t *testing.T, repo repository.BookRepository) {
+	first := mustCreate(t, repo, newBook("First"))
+	require.NoError(t, repo.Delete(ctx, first.ID, 0))
+
+	second := mustCreate(t, repo, newBook("Second"))
+	assert.Greater(t, second.ID, first.ID)
+}
+
+func testDeleteMovesToTrash(t *testing.T, repo repository.BookRepository) {
+	kept := mustCreate(t, repo, newBook("Kept"))
+	deleted := mustCreate(t, repo, newBook("Trashed"))
+	require.NoError(t, repo.Delete(ctx, deleted.ID, deleted.Version))
+
+	assert.Equal(t, []string{"Kept"}, titles(listAll(t, repo, models.ListBooksQuery{})))
+	trash := listAll(t, repo, models.ListBooksQuery{Filter: models.BookFilter{Deleted: true}})
+	require.Len(t, trash, 1)
+	assert.Equal(t, deleted.ID, trash[0].ID)
+	assert.NotNil(t, trash[0].DeletedAt)
+
+	got, err := repo.FindDeleted(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, deleted.Version+1, got.Version)
+	require.NotNil(t, got.DeletedAt)
+	assert.False(t, got.DeletedAt.Before(deleted.CreatedAt))
+	assert.Len(t, got.Contributors, 1, "contributors survive in the trash")
+
+	// A trashed book is gone for every other operation.
+	deleted.Version = 0
+	assertNotFound(t, repo.Update(ctx, deleted))
+	assertNotFound(t, repo.UpdateFields(ctx, deleted, []models.BookField{models.FieldTitle}))
+	assertNotFound(t, repo.Delete(ctx, deleted.ID, 0))
+	_, err = repo.FindDeleted(ctx, kept.ID)
+	assertNotFound(t, err)
+}
+
+func testRestoreBringsBookBack(t *testing.T, repo repository.BookRepository) {
+	book := mustCreate(t, repo, newBook("Second Chance"))
+	require.NoError(t, repo.Delete(ctx, book.ID, 0))
+
+	restored, err := repo.Restore(ctx, book.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, book.Version+2, restored.Version)
+	assert.Equal(t, book.Contributors, restored.Contributors)
+
+	got, err := repo.FindByID(ctx, book.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Second Chance", got.Title)
+	assert.Empty(t, listAll(t, repo, models.ListBooksQuery{Filter: models.BookFilter{Deleted: true}}))
+
+	_, err = repo.Restore(ctx, book.ID)
+	assertNotFound(t, err)
+	_, err = repo.Restore(ctx, 999999)
+	assertNotFound(t, err)
+}
+
+func testRestoreRejectsTakenISBN(t *testing.T, repo repository.BookRepository) {
+	first := newBook("First Printing")
+	first.ISBN = "9780306406157"
+	mustCreate(t, repo, first)
+	require.NoError(t, repo.Delete(ctx, first.ID, 0))
+
+	// The ISBN of a trashed book is free for a new one.
+	second := newBook("Second Printing")
+	second.ISBN = first.ISBN
+	mustCreate(t, repo, second)
+	live := listAll(t, repo, models.ListBooksQuery{Filter: models.BookFilter{ISBN: first.ISBN}})
+	assert.Equal(t, []string{"Second Printing"}, titles(live))
+
+	_, err := repo.Restore(ctx, first.ID)
+	assert.True(t, errors.Is(err, errs.ErrConflict), "restoring onto a taken ISBN conflicts, got %v", err)
+	_, err = repo.FindDeleted(ctx, first.ID)
+	assert.NoError(t, err, "the book stays in the trash")
+
+	require.NoError(t, repo.Delete(ctx, second.ID, 0))
+	_, err = repo.Restore(ctx, first.ID)
+	assert.NoError(t, err)
+}
+
+func testPurgeRemovesOldDeletes(t *testing.T, repo repository.BookRepository) {
+	mustCreate(t, repo, newBook("Live"))
+	for _, title := range []string{"Old", "Older"} {
+		book := mustCreate(t, repo, newBook(title))
+		require.NoError(t, repo.Delete(ctx, book.ID, 0))
+	}
+
+	n, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n, "nothing was deleted an hour ago")
+	require.Len(t, listAll(t, repo, models.ListBooksQuery{Filter: models.BookFilter{Deleted: true}}), 2)
+
+	n, err = repo.Purge(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Empty(t, listAll(t, repo, models.ListBooksQuery{Filter: models.BookFilter{Deleted: true}}))
+	assert.Equal(t, []string{"Live"}, titles(listAll(t, repo, models.ListBooksQuery{})))
+}
+
+func testPurgeRecordsBooksInIDOrder(t *testing.T, repo repository.BookRepository) {
+	var ids []int
+	for i := 0; i < 8; i++ {
+		book := mustCreate(t, repo, newBook(fmt.Sprintf("Trashed %d", i)))
+		require.NoError(t, repo.Delete(ctx, book.ID, 0))
+		ids = append(ids, book.ID)
+	}
+	before, err := repo.Changes(ctx, 0, 100)
+	require.NoError(t, err)
+
+	n, err := repo.Purge(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, len(ids), n)
+
+	purged, err := repo.Changes(ctx, before[len(before)-1].ID, 100)
+	require.NoError(t, err)
+	require.Len(t, purged, len(ids))
+	for i, event := range purged {
+		assert.Equal(t, models.BookPurged, event.Type)
+		assert.Equal(t, ids[i], event.BookID, "books are purged in id order")
+	}
+}
+
+func operations(changes []*models.BookChange) []models.BookOperation {
+	ops := make([]models.BookOperation, len(changes))
+	for i, change := range changes {
+		ops[i] = change.Operation
+	}
+	return ops
+}
+
+func testHistoryRecordsEveryChange(t *testing.T, repo repository.BookRepository) {
+	alice := audit.WithActor(ctx, "alice")
+	book := newBook("Draft")
+	require.NoError(t, repo.Create(alice, book))
+	book.Title = "Final"
+	require.NoError(t, repo.Update(ctx, book))
+	require.NoError(t, repo.Delete(alice, book.ID, book.Version))
+	_, err := repo.Restore(ctx, book.ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, book.ID, 0))
+	_, err = repo.Purge(audit.WithActor(ctx, audit.System), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	changes, err := repo.History(ctx, book.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.BookOperation{
+		models.OpCreate, models.OpUpdate, models.OpDelete, models.OpRestore, models.OpDelete, models.OpPurge,
+	}, operations(changes), "the history outlives the purge")
+	versions := make([]int, len(changes))
+	for i, change := range changes {
+		versions[i] = change.Version
+		assert.Equal(t, book.ID, change.BookID)
+		if i > 0 {
+			assert.Greater(t, change.ID, changes[i-1].ID)
+			assert.False(t, change.ChangedAt.Before(changes[i-1].ChangedAt))
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 5}, versions, "a purge keeps the last version")
+	actors := make([]string, len(changes))
+	for i, change := range changes {
+		actors[i] = change.Actor
+	}
+	assert.Equal(t, []string{"alice", audit.Anonymous, "alice", audit.Anonymous, audit.Anonymous, audit.System}, actors)
+
+	created := changes[0].Changes
+	require.NotEmpty(t, created)
+	assert.Equal(t, models.FieldTitle, created[0].Field)
+	assert.Empty(t, created[0].Before)
+	assert.JSONEq(t, `"Draft"`, string(created[0].After))
+
+	require.Len(t, changes[1].Changes, 1, "updates list only the fields they changed")
+	assert.Equal(t, models.FieldTitle, changes[1].Changes[0].Field)
+	assert.JSONEq(t, `"Draft"`, string(changes[1].Changes[0].Before))
+	assert.JSONEq(t, `"Final"`, string(changes[1].Changes[0].After))
+	assert.Empty(t, changes[2].Changes)
+
+	_, err = repo.History(ctx, 999999)
+	assertNotFound(t, err)
+}
+
+func testFindAsOfReturnsPastStates(t *testing.T, repo repository.BookRepository) {
+	book := mustCreate(t, repo, newBook("First Edition"))
+	book.Title = "Second Edition"
+	require.NoError(t, repo.Update(ctx, book))
+	require.NoError(t, repo.Delete(ctx, book.ID, 0))
+
+	changes, err := repo.History(ctx, book.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	_, err = repo.FindAsOf(ctx, book.ID, changes[0].ChangedAt.Add(-time.Second))
+	assertNotFound(t, err)
+
+	got, err := repo.FindAsOf(ctx, book.ID, changes[0].ChangedAt)
+	require.NoError(t, err)
+	assert.Equal(t, "First Edition", got.Title)
+	assert.Equal(t, 1, got.Version)
+	assert.Len(t, got.Contributors, 1)
+
+	got, err = repo.FindAsOf(ctx, book.ID, changes[1].ChangedAt)
+	require.NoError(t, err)
+	assert.Equal(t, "Second Edition", got.Title)
+	assert.Equal(t, 2, got.Version)
+
+	_, err = repo.FindAsOf(ctx, book.ID, changes[2].ChangedAt.Add(time.Second))
+	assertNotFound(t, err)
+	_, err = repo.FindAsOf(ctx, 999999, time.Now())
+	assertNotFound(t, err)
+}
+
+func testChangesResumeAfterID(t *testing.T, repo repository.BookRepository) {
+	kept := mustCreate(t, repo, newBook("Kept"))
+	gone := mustCreate(t, repo, newBook("Gone"))
+	kept.Title = "Kept, Revised"
+	require.NoError(t, repo.Update(ctx, kept))
+	require.NoError(t, repo.Delete(ctx, gone.ID, 0))
+	_, err := repo.Purge(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	all, err := repo.Changes(ctx, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+	types := make([]models.BookEventType, len(all))
+	for i, event := range all {
+		types[i] = event.Type
+		if i > 0 {
+			assert.Greater(t, event.ID, all[i-1].ID)
+		}
+	}
+	assert.Equal(t, []models.BookEventType{
+		models.BookCreated, models.BookCreated, models.BookUpdated, models.BookDeleted, models.BookPurged,
+	}, types)
+	assert.Equal(t, kept.ID, all[2].BookID)
+	require.NotNil(t, all[2].Book)
+	assert.Equal(t, "Kept, Revised", all[2].Book.Title)
+	require.Len(t, all[2].Changes, 1)
+	assert.Nil(t, all[4].Book, "purged books have no state")
+
+	history, err := repo.History(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Equal(t, history[1].ID, all[2].ID, "events are numbered by change ID")
+
+	next, err := repo.Changes(ctx, all[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, next, 2)
+	assert.Equal(t, all[2].ID, next[0].ID)
+	assert.Equal(t, all[3].ID, next[1].ID)
+
+	none, err := repo.Changes(ctx, all[4].ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func testListOrdersByIDByDefault(t *testing.T, repo repository.BookRepository) {
+	for _, title := range []string{"C", "A", "B"} {
+		mustCreate(t, repo, newBook(title))
+	}
+
+	books := listAll(t, repo, models.ListBooksQuery{})
+	require.Len(t, books, 3)
+	assert.Equal(t, []string{"C", "A", "B"}, titles(books))
+}
+
+func testListPaginatesWithoutGaps(t *testing.T, repo repository.BookRepository) {
+	var want []int
+	for i := 0; i < 7; i++ {
+		want = append(want, mustCreate(t, repo, newBook("Paged")).ID)
+	}
+
+	first, err := repo.List(ctx, models.ListBooksQuery{SortBy: models.SortByID, Limit: 3})
+	require.NoError(t, err)
+	assert.Len(t, first.Books, 3)
+	assert.NotEmpty(t, first.NextPageToken)
+
+	books := listAll(t, repo, models.ListBooksQuery{Limit: 3})
+	got := make([]int, len(books))
+	for i, book := range books {
+		got[i] = book.ID
+	}
+	assert.Equal(t, want, got)
+
+	exact := listAll(t, repo, models.ListBooksQuery{Limit: 7})
+	assert.Len(t, exact, 7, "a full final page must not produce an empty extra page")
+}
+
+func testListSortsWithIDTieBreak(t *testing.T, repo repository.BookRepository) {
+	for _, title := range []string{"banana", "Cherry", "apple", "Banana", "banana"} {
+		mustCreate(t, repo, newBook(title))
+	}
+
+	asc := listAll(t, repo, models.ListBooksQuery{SortBy: models.SortByTitle, Limit: 2})
+	assert.Equal(t, []string{"Banana", "Cherry", "apple", "banana", "banana"}, titles(asc))
+	assert.Less(t, asc[3].ID, asc[4].ID, "equal keys are ordered by ID")
+
+	desc := listAll(t, repo, models.ListBooksQuery{SortBy: models.SortByTitle, Desc: true, Limit: 1})
+	assert.Equal(t, []string{"banana", "banana", "apple", "Cherry", "Banana"}, titles(desc))
+	assert.Greater(t, desc[0].ID, desc[1].ID)
+}
+
+func testListSortsByYearDescending(t *testing.T, repo repository.BookRepository) {
+	for i, year := range []int{1999, 2005, 1999, 2020} {
+		mustCreate(t, repo, &models.Book{Title: string(rune('A' + i)), Author: "Contract Author", BookYear: year})
+	}
+
+	books := listAll(t, repo, models.ListBooksQuery{SortBy: models.SortByYear, Desc: true, Limit: 3})
+	assert.Equal(t, []string{"D", "B", "C", "A"}, titles(books))
+}
+
+func testListFilters(t *testing.T, repo repository.BookRepository) {
+	mustCreate(t, repo, &models.Book{Title: "The Hobbit", Author: "J. R. R. Tolkien", BookYear: 1937})
+	mustCreate(t, repo, &models.Book{Title: "The Silmarillion", Author: "J. R. R. Tolkien", BookYear: 1977})
+	mustCreate(t, repo, &models.Book{Title: "100% Hobbit_Free", Author: "Someone Else", BookYear: 1977})
+	mustCreate(t, repo, &models.Book{Title: "Dune", Author: "Frank Herbert", BookYear: 1965})
+
+	cases := []struct {
+		name   string
+		filter models.BookFilter
+		want   []string
+	}{
+		{"author is case-insensitive", models.BookFilter{Author: "j. r. r. tolkien"}, []string{"The Hobbit", "The Silmarillion"}},
+		{"year range is inclusive", models.BookFilter{YearFrom: 1965, YearTo: 1977}, []string{"The Silmarillion", "100% Hobbit_Free", "Dune"}},
+		{"open-ended year range", models.BookFilter{YearFrom: 1970}, []string{"The Silmarillion", "100% Hobbit_Free"}},
+		{"title contains is case-insensitive", models.BookFilter{TitleContains: "HOBBIT"}, []string{"The Hobbit", "100% Hobbit_Free"}},
+		{"title contains treats wildcards literally", models.BookFilter{TitleContains: "0% h"}, []string{"100% Hobbit_Free"}},
+		{"filters combine", models.BookFilter{Author: "J. R. R. Tolkien", YearFrom: 1950}, []string{"The Silmarillion"}},
+	}
+	for _, tc := range cases {
+		books := listAll(t, repo, models.ListBooksQuery{Filter: tc.filter, Limit: 1})
+		assert.Equal(t, tc.want, titles(books), tc.name)
+	}
+}
+
+func testListRejectsMismatchedPageToken(t *testing.T, repo repository.BookRepository) {
+	for i := 0; i < 3; i++ {
+		mustCreate(t, repo, newBook("Token"))
+	}
+
+	page, err := repo.List(ctx, models.ListBooksQuery{SortBy: models.SortByTitle, Limit: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextPageToken)
+
+	_, err = repo.List(ctx, models.ListBooksQuery{SortBy: models.SortByYear, Limit: 1, PageToken: page.NextPageToken})
+	assert.True(t, errors.Is(err, errs.ErrValidation), "expected validation error, got %v", err)
+
+	_, err = repo.List(ctx, models.ListBooksQuery{SortBy: models.SortByID, Limit: 1, PageToken: "not a token"})
+	assert.True(t, errors.Is(err, errs.ErrValidation), "expected validation error, got %v", err)
+
+	filtered := models.ListBooksQuery{Filter: models.BookFilter{TitleContains: "tok"}, Limit: 1}
+	page, err = repo.List(ctx, filtered)
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextPageToken)
+
+	for _, filter := range []models.BookFilter{{}, {TitleContains: "ken"}, {TitleContains: "tok", Deleted: true}} {
+		_, err = repo.List(ctx, models.ListBooksQuery{Filter: filter, Limit: 1, PageToken: page.NextPageToken})
+		assert.True(t, errors.Is(err, errs.ErrValidation), "filter %+v: expected validation error, got %v", filter, err)
+	}
+	_, err = repo.List(ctx, models.ListBooksQuery{Filter: models.BookFilter{TitleContains: "TOK"}, Limit: 1, PageToken: page.NextPageToken})
+	assert.NoError(t, err, "filters that match the same books share tokens")
+}
+
+func search(t *testing.T, repo repository.BookRepository, query string) []*models.SearchResult {
+	t.Helper()
+	results, err := repo.Search(ctx, models.SearchQuery{Query: query, Limit: 10})
+	require.NoError(t, err)
+	for i := 1; i < len(results); i++ {
+		assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score, "results must be ordered by score")
+	}
+	return results
+}
+
+func resultTitles(results []*models.SearchResult) []string {
+	out := make([]string, len(results))
+	for i, res := range results {
+		out[i] = res.Book.Title
+	}
+	return out
+}
+
+func testSearchRanksTitleAboveAuthor(t *testing.T, repo repository.BookRepository) {
+	mustCreate(t, repo, &models.Book{Title: "Poems", Author: "Dune Collective", BookYear: 1990})
+	mustCreate(t, repo, &models.Book{Title: "Dune", Author: "Frank Herbert", BookYear: 1965})
+	mustCreate(t, repo, &models.Book{Title: "Neuromancer", Author: "William Gibson", BookYear: 1984})
+
+	assert.Equal(t, []string{"Dune", "Poems"}, resultTitles(search(t, repo, "dune")))
+	assert.Empty(t, search(t, repo, "zzzzzz"))
+}
+
+func testSearchMatchesPrefixesAndTypos(t *testing.T, repo repository.BookRepository) {
+	mustCreate(t, repo, &models.Book{Title: "The Hobbit", Author: "J. R. R. Tolkien", BookYear: 1937})
+	mustCreate(t, repo, &models.Book{Title: "Dune", Author: "Frank Herbert", BookYear: 1965})
+
+	assert.Equal(t, []string{"The Hobbit"}, resultTitles(search(t, repo, "hobb")), "prefix")
+	assert.Equal(t, []string{"The Hobbit"}, resultTitles(search(t, repo, "tolkein")), "typo")
+	assert.Equal(t, []string{"The Hobbit"}, resultTitles(search(t, repo, "Hobbit Tolkien")), "multiple terms")
+}
+
+func testSearchHighlights(t *testing.T, repo repository.BookRepository) {
+	mustCreate(t, repo, &models.Book{Title: "The Hobbit", Author: "J. R. R. Tolkien", BookYear: 1937})
+
+	results := search(t, repo, "hobbit")
+	require.Len(t, results, 1)
+	assert.Equal(t, "The "+models.HighlightStart+"Hobbit"+models.HighlightStop, results[0].Highlights.Title)
+	assert.Equal(t, "J. R. R. Tolkien", results[0].Highlights.Author)
+	assert.Positive(t, results[0].Score)
+}
+
+func testSearchFollowsUpdatesAndDeletes(t *testing.T, repo repository.BookRepository) {
+	book := mustCreate(t, repo, &models.Book{Title: "Foundation", Author: "Isaac Asimov", BookYear: 1951})
+
+	book.Title = "Robots"
+	require.NoError(t, repo.Update(ctx, book))
+	assert.Empty(t, search(t, repo, "foundation"))
+	assert.Len(t, search(t, repo, "robots"), 1)
+
+	require.NoError(t, repo.Delete(ctx, book.ID, 0))
+	assert.Empty(t, search(t, repo, "robots"))
+}
+
+func testConcurrentCreates(t *testing.T, repo repository.BookRepository) {
+	const writers = 20
+
+	var wg sync.WaitGroup
+	ids := make(chan int, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			book := newBook("Concurrent")
+			if assert.NoError(t, repo.Create(ctx, book)) {
+				ids <- book.ID
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := map[int]bool{}
+	for id := range ids {
+		assert.False(t, seen[id], "duplicate ID %d", id)
+		seen[id] = true
+	}
+	assert.Len(t, seen, writers)
+
+	assert.Len(t, listAll(t, repo, models.ListBooksQuery{}), writers)
+}
+
+func testObservesCancellation(t *testing.T, repo repository.BookRepository) {
+	book := mustCreate(t, repo, newBook("Cancelled"))
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.FindByID(cancelled, book.ID)
+	assert.True(t, errors.Is(err, context.Canceled), "FindByID: got %v", err)
+	_, err = repo.List(cancelled, models.ListBooksQuery{SortBy: models.SortByID, Limit: 10})
+	assert.True(t, errors.Is(err, context.Canceled), "List: got %v", err)
+	err = repo.Create(cancelled, newBook("Never stored"))
+	assert.True(t, errors.Is(err, context.Canceled), "Create: got %v", err)
+	assert.Len(t, listAll(t, repo, models.ListBooksQuery{}), 1)
+}
@@ -0,0 +1,177 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// OutboxRepositoryFactory returns empty book and outbox repositories that
+// share storage, for a single subtest.
+type OutboxRepositoryFactory func(t *testing.T) (repository.BookRepository, repository.OutboxRepository)
+
+// RunOutboxRepositoryContract runs the conformance suite for the outbox
+// against the adapters produced by newRepos.
+func RunOutboxRepositoryContract(t *testing.T, newRepos OutboxRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, books repository.BookRepository, events repository.OutboxRepository)
+	}{
+		{"ChangesQueueEvents", testChangesQueueEvents},
+		{"ClaimKeepsBookOrder", testClaimKeepsBookOrder},
+		{"FailedEventsWaitForRetry", testFailedEventsWaitForRetry},
+		{"ExpiredLeasesOfferAgain", testExpiredLeasesOfferAgain},
+		{"DeletePublishedKeepsPending", testDeletePublishedKeepsPending},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			books, events := newRepos(t)
+			tc.run(t, books, events)
+		})
+	}
+}
+
+// drain claims and publishes every due event, one claim at a time, and
+// returns them in the order they were claimed.
+func drain(t *testing.T, events repository.OutboxRepository) []*models.BookEvent {
+	t.Helper()
+	var all []*models.BookEvent
+	for {
+		batch, err := events.Claim(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		if len(batch) == 0 {
+			return all
+		}
+		for _, event := range batch {
+			require.NoError(t, events.MarkPublished(ctx, event.ID))
+		}
+		all = append(all, batch...)
+	}
+}
+
+func testChangesQueueEvents(t *testing.T, books repository.BookRepository, events repository.OutboxRepository) {
+	alice := audit.WithActor(ctx, "alice")
+	book := newBook("Outbound")
+	require.NoError(t, books.Create(alice, book))
+	book.Title = "Outbound, Revised"
+	require.NoError(t, books.Update(alice, book))
+	require.NoError(t, books.Delete(alice, book.ID, 0))
+	_, err := books.Restore(alice, book.ID)
+	require.NoError(t, err)
+	require.NoError(t, books.Delete(alice, book.ID, 0))
+	_, err = books.Purge(alice, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	got := drain(t, events)
+	types := make([]models.BookEventType, len(got))
+	for i, event := range got {
+		types[i] = event.Type
+		assert.Equal(t, book.ID, event.BookID)
+		assert.Equal(t, "alice", event.Actor)
+		assert.False(t, event.OccurredAt.IsZero())
+		assert.Zero(t, event.Attempts)
+		if i > 0 {
+			assert.Greater(t, event.ID, got[i-1].ID)
+		}
+	}
+	assert.Equal(t, []models.BookEventType{
+		models.BookCreated, models.BookUpdated, models.BookDeleted, models.BookRestored, models.BookDeleted, models.BookPurged,
+	}, types)
+
+	require.NotNil(t, got[0].Book)
+	assert.Equal(t, "Outbound", got[0].Book.Title)
+	assert.Equal(t, 1, got[0].Version)
+	require.NotNil(t, got[1].Book)
+	assert.Equal(t, "Outbound, Revised", got[1].Book.Title)
+	require.Len(t, got[1].Changes, 1)
+	assert.Equal(t, models.FieldTitle, got[1].Changes[0].Field)
+	require.NotNil(t, got[2].Book)
+	assert.NotNil(t, got[2].Book.DeletedAt)
+	assert.Nil(t, got[5].Book, "purged books have no state")
+	assert.Equal(t, 5, got[5].Version)
+}
+
+func testClaimKeepsBookOrder(t *testing.T, books repository.BookRepository, events repository.OutboxRepository) {
+	first := mustCreate(t, books, newBook("First"))
+	first.Title = "First, Again"
+	require.NoError(t, books.Update(ctx, first))
+	second := mustCreate(t, books, newBook("Second"))
+
+	batch, err := events.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, batch, 2, "an event waits for the earlier events of its book")
+	assert.Equal(t, first.ID, batch[0].BookID)
+	assert.Equal(t, models.BookCreated, batch[0].Type)
+	assert.Equal(t, second.ID, batch[1].BookID)
+
+	again, err := events.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again, "claimed events are leased")
+
+	require.NoError(t, events.MarkPublished(ctx, batch[0].ID))
+	next, err := events.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+	assert.Equal(t, first.ID, next[0].BookID)
+	assert.Equal(t, models.BookUpdated, next[0].Type)
+
+	assertNotFound(t, events.MarkPublished(ctx, 999999))
+	assertNotFound(t, events.MarkFailed(ctx, 999999, "boom", time.Now()))
+}
+
+func testFailedEventsWaitForRetry(t *testing.T, books repository.BookRepository, events repository.OutboxRepository) {
+	mustCreate(t, books, newBook("Flaky"))
+	batch, err := events.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	id := batch[0].ID
+
+	require.NoError(t, events.MarkFailed(ctx, id, "broker unavailable", time.Now().Add(time.Hour)))
+	batch, err = events.Claim(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, batch, "not due until the retry time")
+
+	require.NoError(t, events.MarkFailed(ctx, id, "broker unavailable", time.Now().Add(-time.Second)))
+	batch, err = events.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	assert.Equal(t, id, batch[0].ID)
+	assert.Equal(t, 2, batch[0].Attempts)
+}
+
+func testExpiredLeasesOfferAgain(t *testing.T, books repository.BookRepository, events repository.OutboxRepository) {
+	mustCreate(t, books, newBook("Abandoned"))
+	batch, err := events.Claim(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+
+	again, err := events.Claim(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, again, 1, "a claimer that never settles loses the event")
+	assert.Equal(t, batch[0].ID, again[0].ID)
+	assert.Zero(t, again[0].Attempts, "a lapsed lease isn't a failed attempt")
+}
+
+func testDeletePublishedKeepsPending(t *testing.T, books repository.BookRepository, events repository.OutboxRepository) {
+	mustCreate(t, books, newBook("Published"))
+	require.Len(t, drain(t, events), 1)
+	mustCreate(t, books, newBook("Pending"))
+
+	n, err := events.DeletePublished(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	n, err = events.DeletePublished(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	pending := drain(t, events)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Pending", pending[0].Book.Title)
+}
@@ -0,0 +1,256 @@
+package repotest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// WebhookRepositoryFactory returns an empty repository for a single subtest.
+type WebhookRepositoryFactory func(t *testing.T) repository.WebhookRepository
+
+// RunWebhookRepositoryContract runs the conformance suite for webhooks
+// against the adapters produced by newRepo.
+func RunWebhookRepositoryContract(t *testing.T, newRepo WebhookRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, repo repository.WebhookRepository)
+	}{
+		{"CreateFindListDelete", testWebhookCreateFindListDelete},
+		{"EnqueueMatchesEventTypesOnce", testEnqueueMatchesEventTypesOnce},
+		{"ClaimKeepsBookOrderPerWebhook", testClaimKeepsBookOrderPerWebhook},
+		{"FailuresRetryAndDisable", testFailuresRetryAndDisable},
+		{"GivingUpFailsDelivery", testGivingUpFailsDelivery},
+		{"ListDeliveriesNewestFirst", testListDeliveriesNewestFirst},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newRepo(t))
+		})
+	}
+}
+
+func mustCreateWebhook(t *testing.T, repo repository.WebhookRepository, types ...models.BookEventType) *models.Webhook {
+	t.Helper()
+	webhook := &models.Webhook{URL: "https://example.com/hooks", EventTypes: types, Secret: "s3cret"}
+	require.NoError(t, repo.Create(ctx, webhook))
+	return webhook
+}
+
+func mustEnqueue(t *testing.T, repo repository.WebhookRepository, id, bookID int, typ models.BookEventType) int {
+	t.Helper()
+	n, err := repo.Enqueue(ctx, &models.BookEvent{ID: id, Type: typ, BookID: bookID, Version: 1})
+	require.NoError(t, err)
+	return n
+}
+
+func claimAll(t *testing.T, repo repository.WebhookRepository) []*models.WebhookDelivery {
+	t.Helper()
+	deliveries, err := repo.ClaimDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	return deliveries
+}
+
+// failed is a failed attempt that got status, or no response when it is 0.
+func failed(status int, retryAt *time.Time) models.DeliveryAttempt {
+	reason := "connection refused"
+	if status != 0 {
+		reason = http.StatusText(status)
+	}
+	return models.DeliveryAttempt{ResponseStatus: status, Error: reason, RetryAt: retryAt}
+}
+
+func testWebhookCreateFindListDelete(t *testing.T, repo repository.WebhookRepository) {
+	first := mustCreateWebhook(t, repo, models.BookCreated, models.BookDeleted)
+	assert.NotZero(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+	assert.Nil(t, first.DisabledAt)
+	second := mustCreateWebhook(t, repo)
+
+	found, err := repo.FindByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hooks", found.URL)
+	assert.Equal(t, []models.BookEventType{models.BookCreated, models.BookDeleted}, found.EventTypes)
+	assert.Equal(t, "s3cret", found.Secret, "the repository keeps the secret")
+
+	all, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, first.ID, all[0].ID)
+	assert.Equal(t, second.ID, all[1].ID)
+	assert.Empty(t, all[1].EventTypes)
+
+	require.Equal(t, 2, mustEnqueue(t, repo, 1, 7, models.BookCreated))
+	require.NoError(t, repo.Delete(ctx, first.ID))
+	_, err = repo.FindByID(ctx, first.ID)
+	assertNotFound(t, err)
+	_, err = repo.ListDeliveries(ctx, first.ID, 10)
+	assertNotFound(t, err)
+	assertNotFound(t, repo.Delete(ctx, first.ID))
+	_, err = repo.Enable(ctx, first.ID)
+	assertNotFound(t, err)
+
+	deliveries := claimAll(t, repo)
+	require.Len(t, deliveries, 1, "deleting a webhook drops its deliveries")
+	assert.Equal(t, second.ID, deliveries[0].WebhookID)
+}
+
+func testEnqueueMatchesEventTypesOnce(t *testing.T, repo repository.WebhookRepository) {
+	all := mustCreateWebhook(t, repo)
+	deletes := mustCreateWebhook(t, repo, models.BookDeleted)
+
+	assert.Equal(t, 1, mustEnqueue(t, repo, 1, 7, models.BookCreated))
+	assert.Equal(t, 2, mustEnqueue(t, repo, 2, 7, models.BookDeleted))
+	assert.Zero(t, mustEnqueue(t, repo, 2, 7, models.BookDeleted), "an event is delivered once per webhook")
+
+	deliveries, err := repo.ListDeliveries(ctx, deletes.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	d := deliveries[0]
+	assert.Equal(t, 2, d.EventID)
+	assert.Equal(t, models.BookDeleted, d.EventType)
+	assert.Equal(t, 7, d.BookID)
+	assert.Equal(t, models.DeliveryPending, d.Status)
+	assert.Zero(t, d.Attempts)
+	assert.NotNil(t, d.NextAttemptAt)
+	var event models.BookEvent
+	require.NoError(t, json.Unmarshal(d.Payload, &event))
+	assert.Equal(t, 2, event.ID)
+	assert.Equal(t, models.BookDeleted, event.Type)
+
+	deliveries, err = repo.ListDeliveries(ctx, all.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+}
+
+func testClaimKeepsBookOrderPerWebhook(t *testing.T, repo repository.WebhookRepository) {
+	first := mustCreateWebhook(t, repo)
+	second := mustCreateWebhook(t, repo)
+	mustEnqueue(t, repo, 1, 7, models.BookCreated)
+	mustEnqueue(t, repo, 2, 7, models.BookUpdated)
+	mustEnqueue(t, repo, 3, 8, models.BookCreated)
+
+	batch := claimAll(t, repo)
+	require.Len(t, batch, 4, "a delivery waits for the earlier deliveries of its book to the same webhook")
+	for _, d := range batch {
+		assert.Contains(t, []int{1, 3}, d.EventID)
+	}
+	for i := 1; i < len(batch); i++ {
+		assert.Greater(t, batch[i].ID, batch[i-1].ID)
+	}
+	assert.Empty(t, claimAll(t, repo), "claimed deliveries are leased")
+
+	var settle int
+	for _, d := range batch {
+		if d.WebhookID == first.ID && d.EventID == 1 {
+			settle = d.ID
+		}
+	}
+	_, err := repo.RecordAttempt(ctx, settle, models.DeliveryAttempt{ResponseStatus: http.StatusOK}, 0)
+	require.NoError(t, err)
+	next := claimAll(t, repo)
+	require.Len(t, next, 1)
+	assert.Equal(t, first.ID, next[0].WebhookID)
+	assert.Equal(t, 2, next[0].EventID)
+	assert.NotEqual(t, second.ID, next[0].WebhookID)
+
+	_, err = repo.RecordAttempt(ctx, 999999, models.DeliveryAttempt{ResponseStatus: http.StatusOK}, 0)
+	assertNotFound(t, err)
+}
+
+func testFailuresRetryAndDisable(t *testing.T, repo repository.WebhookRepository) {
+	webhook := mustCreateWebhook(t, repo)
+	mustEnqueue(t, repo, 1, 7, models.BookCreated)
+	batch := claimAll(t, repo)
+	require.Len(t, batch, 1)
+	id := batch[0].ID
+
+	later := time.Now().Add(time.Hour)
+	updated, err := repo.RecordAttempt(ctx, id, failed(http.StatusInternalServerError, &later), 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.FailureCount)
+	assert.Nil(t, updated.DisabledAt)
+	assert.Empty(t, claimAll(t, repo), "not due until the retry time")
+
+	deliveries, err := repo.ListDeliveries(ctx, webhook.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, models.DeliveryPending, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].ResponseStatus)
+	assert.Equal(t, "Internal Server Error", deliveries[0].LastError)
+	require.NotNil(t, deliveries[0].NextAttemptAt)
+	assert.WithinDuration(t, later, *deliveries[0].NextAttemptAt, time.Second)
+
+	past := time.Now().Add(-time.Second)
+	_, err = repo.RecordAttempt(ctx, id, failed(http.StatusBadGateway, &past), 3)
+	require.NoError(t, err)
+	updated, err = repo.RecordAttempt(ctx, id, failed(0, &past), 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated.FailureCount)
+	require.NotNil(t, updated.DisabledAt, "disabled after repeated failures")
+	assert.Empty(t, claimAll(t, repo), "disabled webhooks get no deliveries")
+	assert.Zero(t, mustEnqueue(t, repo, 2, 8, models.BookCreated), "disabled webhooks get no new events")
+
+	enabled, err := repo.Enable(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.Nil(t, enabled.DisabledAt)
+	assert.Zero(t, enabled.FailureCount)
+	batch = claimAll(t, repo)
+	require.Len(t, batch, 1)
+	assert.Equal(t, id, batch[0].ID)
+	assert.Equal(t, 3, batch[0].Attempts)
+
+	updated, err = repo.RecordAttempt(ctx, id, models.DeliveryAttempt{ResponseStatus: http.StatusNoContent}, 3)
+	require.NoError(t, err)
+	assert.Zero(t, updated.FailureCount)
+	deliveries, err = repo.ListDeliveries(ctx, webhook.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, models.DeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, 4, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].ResponseStatus)
+	assert.Empty(t, deliveries[0].LastError)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+	assert.Nil(t, deliveries[0].NextAttemptAt)
+}
+
+func testGivingUpFailsDelivery(t *testing.T, repo repository.WebhookRepository) {
+	webhook := mustCreateWebhook(t, repo)
+	mustEnqueue(t, repo, 1, 7, models.BookCreated)
+	mustEnqueue(t, repo, 2, 7, models.BookUpdated)
+	batch := claimAll(t, repo)
+	require.Len(t, batch, 1)
+
+	_, err := repo.RecordAttempt(ctx, batch[0].ID, failed(http.StatusGone, nil), 0)
+	require.NoError(t, err)
+	next := claimAll(t, repo)
+	require.Len(t, next, 1, "a failed delivery no longer holds up its book")
+	assert.Equal(t, 2, next[0].EventID)
+
+	deliveries, err := repo.ListDeliveries(ctx, webhook.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, models.DeliveryFailed, deliveries[1].Status)
+	assert.Equal(t, http.StatusGone, deliveries[1].ResponseStatus)
+	assert.Nil(t, deliveries[1].NextAttemptAt)
+	assert.Nil(t, deliveries[1].DeliveredAt)
+}
+
+func testListDeliveriesNewestFirst(t *testing.T, repo repository.WebhookRepository) {
+	webhook := mustCreateWebhook(t, repo)
+	for i := 1; i <= 3; i++ {
+		mustEnqueue(t, repo, i, i, models.BookCreated)
+	}
+	deliveries, err := repo.ListDeliveries(ctx, webhook.ID, 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, 3, deliveries[0].EventID)
+	assert.Equal(t, 2, deliveries[1].EventID)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+type authorHandler struct {
+	authorUsecase interfaces.AuthorUsecase
+}
+
+func NewAuthorHandler(authorUsecase interfaces.AuthorUsecase) interfaces.AuthorHandler {
+	return &authorHandler{authorUsecase}
+}
+
+func (h *authorHandler) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+	var author models.Author
+	if err := json.NewDecoder(r.Body).Decode(&author); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authorUsecase.AddAuthor(r.Context(), &author); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(author)
+}
+
+// GetAuthors handles GET /authors?name_contains=...&limit=...&page_token=...
+// Like GET /books, the next page token is returned in the X-Next-Page-Token header.
+func (h *authorHandler) GetAuthors(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := models.ListAuthorsQuery{
+		NameContains: q.Get("name_contains"),
+		PageToken:    q.Get("page_token"),
+	}
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			http.Error(w, "limit must be a number", http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
+
+	page, err := h.authorUsecase.ListAuthors(r.Context(), query)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	authors := page.Authors
+	if authors == nil {
+		authors = []*models.Author{}
+	}
+	if page.NextPageToken != "" {
+		w.Header().Set(NextPageTokenHeader, page.NextPageToken)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(authors)
+}
+
+func (h *authorHandler) GetAuthor(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	author, err := h.authorUsecase.GetAuthorByID(r.Context(), id)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(author)
+}
+
+func (h *authorHandler) UpdateAuthor(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var author models.Author
+	if err := json.NewDecoder(r.Body).Decode(&author); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	author.ID = id
+	if err := h.authorUsecase.UpdateAuthor(r.Context(), &author); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(author)
+}
+
+func (h *authorHandler) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authorUsecase.DeleteAuthor(r.Context(), id); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAuthorBooks handles GET /authors/{id}/books, accepting the same
+// parameters as GET /books.
+func (h *authorHandler) GetAuthorBooks(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	query, err := parseListQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.authorUsecase.ListAuthorBooks(r.Context(), id, query)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	books := page.Books
+	if books == nil {
+		books = []*models.Book{}
+	}
+	if page.NextPageToken != "" {
+		w.Header().Set(NextPageTokenHeader, page.NextPageToken)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(books)
+}
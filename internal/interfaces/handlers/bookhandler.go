@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Dias221467/MicroServices/internal/domain/models"
 	"github.com/Dias221467/MicroServices/internal/interfaces"
@@ -12,10 +13,21 @@ import (
 
 type bookHandler struct {
 	bookUsecase interfaces.BookUsecase
+	changePoll  time.Duration
+	heartbeat   time.Duration
+	streamsDone <-chan struct{}
 }
 
-func NewBookHandler(bookUsecase interfaces.BookUsecase) interfaces.BookHandler {
-	return &bookHandler{bookUsecase}
+func NewBookHandler(bookUsecase interfaces.BookUsecase, opts ...BookHandlerOption) interfaces.BookHandler {
+	h := &bookHandler{
+		bookUsecase: bookUsecase,
+		changePoll:  time.Second,
+		heartbeat:   15 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *bookHandler) CreateBook(w http.ResponseWriter, r *http.Request) {
@@ -25,31 +37,65 @@ func (h *bookHandler) CreateBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if book.Title == "" || book.Author == "" || book.BookYear == 0 {
-		http.Error(w, "Title, author, and year are required", http.StatusBadRequest)
-		return
-	}
-
-	if err := h.bookUsecase.AddBook(&book); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.bookUsecase.AddBook(r.Context(), &book); err != nil {
+		WriteError(w, err)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(book.Version))
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(book)
 }
 
+// GetBooks lists one page of books. The body stays a plain JSON array; the
+// token for the following page is returned in the X-Next-Page-Token header.
 func (h *bookHandler) GetBooks(w http.ResponseWriter, r *http.Request) {
-	books, err := h.bookUsecase.GetBooks()
+	query, err := parseListQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.bookUsecase.ListBooks(r.Context(), query)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteError(w, err)
 		return
 	}
 
+	books := page.Books
+	if books == nil {
+		books = []*models.Book{}
+	}
+	if page.NextPageToken != "" {
+		w.Header().Set(NextPageTokenHeader, page.NextPageToken)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(books)
 }
 
+// SearchBooks handles GET /books/search?q=...&limit=...
+func (h *bookHandler) SearchBooks(w http.ResponseWriter, r *http.Request) {
+	query := models.SearchQuery{Query: r.URL.Query().Get("q")}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			http.Error(w, "limit must be a number", http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
+
+	results, err := h.bookUsecase.SearchBooks(r.Context(), query)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
 func (h *bookHandler) GetBook(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id, err := strconv.Atoi(params["id"])
@@ -58,16 +104,36 @@ func (h *bookHandler) GetBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	book, err := h.bookUsecase.GetBookByID(id)
+	if asOf := r.URL.Query().Get("as_of"); asOf != "" {
+		at, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			http.Error(w, "as_of must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		// No ETag: a past version is not one that changes can be based on.
+		book, err := h.bookUsecase.GetBookAsOf(r.Context(), id, at)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(book)
+		return
+	}
+
+	book, err := h.bookUsecase.GetBookByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		WriteError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(book.Version))
 	json.NewEncoder(w).Encode(book)
 }
 
+// UpdateBook replaces a book. The If-Match header must carry the ETag the
+// change is based on, so that concurrent edits aren't silently lost.
 func (h *bookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id, err := strconv.Atoi(params["id"])
@@ -75,6 +141,11 @@ func (h *bookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
 
 	var book models.Book
 	if err := json.NewDecoder(r.Body).Decode(&book); err != nil {
@@ -82,21 +153,68 @@ func (h *bookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if book.Title == "" || book.Author == "" || book.BookYear == 0 {
-		http.Error(w, "Title, author, and year are required", http.StatusBadRequest)
+	book.ID = id
+	book.Version = version
+	if err := h.bookUsecase.UpdateBook(r.Context(), &book); err != nil {
+		WriteError(w, err)
 		return
 	}
 
-	book.ID = id
-	if err := h.bookUsecase.UpdateBook(&book); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(book.Version))
+	json.NewEncoder(w).Encode(book)
+}
+
+// DeleteBook moves a book to the trash; like UpdateBook it requires If-Match.
+func (h *bookHandler) DeleteBook(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	if err := h.bookUsecase.DeleteBook(r.Context(), id, version); err != nil {
+		WriteError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *bookHandler) DeleteBook(w http.ResponseWriter, r *http.Request) {
+// GetDeletedBooks lists one page of the trash, with the same parameters and
+// paging as GetBooks.
+func (h *bookHandler) GetDeletedBooks(w http.ResponseWriter, r *http.Request) {
+	query, err := parseListQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.bookUsecase.ListDeletedBooks(r.Context(), query)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	books := page.Books
+	if books == nil {
+		books = []*models.Book{}
+	}
+	if page.NextPageToken != "" {
+		w.Header().Set(NextPageTokenHeader, page.NextPageToken)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(books)
+}
+
+// RestoreBook handles POST /books/{id}:restore, taking a book out of the trash.
+func (h *bookHandler) RestoreBook(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id, err := strconv.Atoi(params["id"])
 	if err != nil {
@@ -104,10 +222,32 @@ func (h *bookHandler) DeleteBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.bookUsecase.DeleteBook(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	book, err := h.bookUsecase.RestoreBook(r.Context(), id)
+	if err != nil {
+		WriteError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(book.Version))
+	json.NewEncoder(w).Encode(book)
+}
+
+// GetBookHistory returns every change made to a book, oldest first.
+func (h *bookHandler) GetBookHistory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	changes, err := h.bookUsecase.GetBookHistory(r.Context(), id)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(changes)
 }
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// LastEventIDHeader is sent by reconnecting EventSource clients with the ID
+// of the last event they received.
+const LastEventIDHeader = "Last-Event-ID"
+
+// changePageSize is how many changes StreamChanges reads at a time.
+const changePageSize = 100
+
+// BookHandlerOption configures the handler returned by NewBookHandler.
+type BookHandlerOption func(*bookHandler)
+
+// WithChangeFeed sets how often GET /books/changes looks for new changes and
+// how long it may stay silent before sending a heartbeat comment, which
+// keeps idle connections from being dropped by proxies. They default to a
+// second and fifteen seconds.
+func WithChangeFeed(poll, heartbeat time.Duration) BookHandlerOption {
+	return func(h *bookHandler) { h.changePoll, h.heartbeat = poll, heartbeat }
+}
+
+// WithStreamsDone ends open streams when done is closed, so that they don't
+// hold up a graceful shutdown; http.Server.Shutdown waits for them otherwise.
+func WithStreamsDone(done <-chan struct{}) BookHandlerOption {
+	return func(h *bookHandler) { h.streamsDone = done }
+}
+
+// StreamChanges handles GET /books/changes, streaming every book change as a
+// server-sent event whose id is the change's sequence number and whose data
+// is the event as published to the outbox. The stream starts after the
+// change named by the Last-Event-ID header, or the last_event_id parameter
+// for clients that can't set headers, and from the first change without
+// either. It runs until the client goes away.
+func (h *bookHandler) StreamChanges(w http.ResponseWriter, r *http.Request) {
+	var after int
+	if s := r.Header.Get(LastEventIDHeader); s != "" || r.URL.Query().Has("last_event_id") {
+		if s == "" {
+			s = r.URL.Query().Get("last_event_id")
+		}
+		id, err := strconv.Atoi(s)
+		if err != nil || id < 0 {
+			http.Error(w, "Last-Event-ID must be a change ID", http.StatusBadRequest)
+			return
+		}
+		after = id
+	}
+
+	events, err := h.bookUsecase.ListChanges(r.Context(), after, changePageSize)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	// The stream outlives the server's write timeout, which would otherwise
+	// cut it off; writers that can't lift it just end the stream early.
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(h.changePoll)
+	defer ticker.Stop()
+	lastWrite := time.Now()
+	for {
+		for _, event := range events {
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			after = event.ID
+		}
+		if len(events) > 0 {
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+
+		// A full page means there may be more waiting already.
+		if len(events) < changePageSize {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-h.streamsDone:
+				return
+			case <-ticker.C:
+			}
+			if time.Since(lastWrite) >= h.heartbeat {
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				if err := rc.Flush(); err != nil {
+					return
+				}
+				lastWrite = time.Now()
+			}
+		}
+
+		if events, err = h.bookUsecase.ListChanges(r.Context(), after, changePageSize); err != nil {
+			// The client reconnects with the last ID it saw and resumes.
+			return
+		}
+	}
+}
+
+// writeEvent writes event in the text/event-stream format.
+func writeEvent(w http.ResponseWriter, event *models.BookEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
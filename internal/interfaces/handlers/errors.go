@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+)
+
+// statusClientClosedRequest is the de-facto status for requests whose client
+// went away before the response was written.
+const statusClientClosedRequest = 499
+
+// HTTPStatus maps a domain error onto the HTTP status code reported to clients.
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	}
+
+	switch errs.KindOf(err) {
+	case errs.KindNotFound:
+		return http.StatusNotFound
+	case errs.KindConflict:
+		return http.StatusConflict
+	case errs.KindValidation:
+		return http.StatusBadRequest
+	case errs.KindUnavailable:
+		return http.StatusServiceUnavailable
+	case errs.KindPrecondition:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WriteError writes err with the status matching its domain kind. Clients get
+// only the domain error's own message, and unclassified errors no detail at
+// all, so that internals such as driver errors don't leak; the full error is
+// logged instead.
+func WriteError(w http.ResponseWriter, err error) {
+	status := HTTPStatus(err)
+	msg := http.StatusText(status)
+	if status != http.StatusInternalServerError && errs.KindOf(err) != errs.KindUnknown {
+		msg = errs.MessageOf(err)
+	}
+	if msg != err.Error() {
+		slog.Warn("request failed", slog.Int("status", status), slog.String("error", err.Error()))
+	}
+	http.Error(w, msg, status)
+}
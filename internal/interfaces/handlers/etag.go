@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+)
+
+// errIfMatchRequired is reported with 428 Precondition Required when a
+// request that changes a book doesn't say which version it is based on.
+var errIfMatchRequired = errors.New("If-Match header is required; use the ETag from GET")
+
+// ETag returns the entity tag of a book at version.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion returns the book version required by the If-Match header,
+// or 0 for "*". Tags this service couldn't have issued, including weak ones,
+// never match and so fail with errs.ErrPrecondition.
+func ifMatchVersion(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch {
+	case header == "":
+		return 0, errIfMatchRequired
+	case header == "*":
+		return 0, nil
+	case strings.Contains(header, ","):
+		return 0, errs.Validation("If-Match must hold a single entity tag")
+	}
+
+	version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(header, `"`), `"`))
+	if err != nil || version <= 0 || ETag(version) != header {
+		return 0, errs.PreconditionFailed("If-Match %s does not match the current version", header)
+	}
+	return version, nil
+}
+
+// writeIfMatchError reports an unusable If-Match header.
+func writeIfMatchError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errIfMatchRequired) {
+		http.Error(w, err.Error(), http.StatusPreconditionRequired)
+		return
+	}
+	WriteError(w, err)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gorilla/mux"
+)
+
+// Media types accepted by PATCH /books/{id}.
+const (
+	MergePatchType = "application/merge-patch+json" // RFC 7396
+	JSONPatchType  = "application/json-patch+json"  // RFC 6902
+)
+
+// PatchBook applies a JSON Merge Patch or JSON Patch, chosen by Content-Type,
+// to the JSON form of a book and stores the fields it changed. Like PUT it
+// requires If-Match. Read-only fields must be left as they are.
+func (h *bookHandler) PatchBook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != MergePatchType && mediaType != JSONPatchType {
+		w.Header().Set("Accept-Patch", MergePatchType+", "+JSONPatchType)
+		http.Error(w, "Content-Type must be "+MergePatchType+" or "+JSONPatchType, http.StatusUnsupportedMediaType)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	current, err := h.bookUsecase.GetBookByID(r.Context(), id)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	patched, err := applyPatch(current, mediaType, patch)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	patched.Version = version
+	if err := h.bookUsecase.PatchBook(r.Context(), patched, models.ChangedFields(current, patched)); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(patched.Version))
+	json.NewEncoder(w).Encode(patched)
+}
+
+// applyPatch returns current with patch applied. A failed JSON Patch "test"
+// operation is a conflict with the current state; other failures are the
+// client's.
+func applyPatch(current *models.Book, mediaType string, patch []byte) (*models.Book, error) {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	if mediaType == MergePatchType {
+		doc, err = jsonpatch.MergePatch(doc, patch)
+	} else {
+		var ops jsonpatch.Patch
+		if ops, err = jsonpatch.DecodePatch(patch); err == nil {
+			doc, err = ops.Apply(doc)
+		}
+	}
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return nil, errs.Conflict("patch test failed: %v", err)
+	case err != nil:
+		return nil, errs.Validation("invalid patch: %v", err)
+	}
+
+	var patched models.Book
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&patched); err != nil {
+		return nil, errs.Validation("patched book is invalid: %v", err)
+	}
+	if field := changedReadOnlyField(current, &patched); field != "" {
+		return nil, errs.Validation("%s is read-only", field)
+	}
+	return &patched, nil
+}
+
+func changedReadOnlyField(current, patched *models.Book) string {
+	switch {
+	case patched.ID != current.ID:
+		return "id"
+	case patched.Version != current.Version:
+		return "version"
+	case !patched.CreatedAt.Equal(current.CreatedAt):
+		return "created_at"
+	case !patched.UpdatedAt.Equal(current.UpdatedAt):
+		return "updated_at"
+	case patched.DeletedAt != nil:
+		return "deleted_at"
+	}
+	return ""
+}
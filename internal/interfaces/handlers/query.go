@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// NextPageTokenHeader carries the page_token for the next page of a listing.
+const NextPageTokenHeader = "X-Next-Page-Token"
+
+// parseListQuery reads limit, page_token, sort, order and the filter
+// parameters of GET /books and GET /books/trash.
+func parseListQuery(r *http.Request) (models.ListBooksQuery, error) {
+	q := r.URL.Query()
+	query := models.ListBooksQuery{
+		SortBy:    models.SortField(q.Get("sort")),
+		PageToken: q.Get("page_token"),
+		Filter: models.BookFilter{
+			Author:        q.Get("author"),
+			TitleContains: q.Get("title_contains"),
+			ISBN:          q.Get("isbn"),
+		},
+	}
+
+	switch strings.ToLower(q.Get("order")) {
+	case "", "asc":
+	case "desc":
+		query.Desc = true
+	default:
+		return query, fmt.Errorf("order must be asc or desc")
+	}
+
+	for name, target := range map[string]*int{
+		"limit":     &query.Limit,
+		"author_id": &query.Filter.AuthorID,
+		"year_from": &query.Filter.YearFrom,
+		"year_to":   &query.Filter.YearTo,
+	} {
+		value := q.Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return query, fmt.Errorf("%s must be a number", name)
+		}
+		*target = n
+	}
+	return query, nil
+}
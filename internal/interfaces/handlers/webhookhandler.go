@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+type webhookHandler struct {
+	webhookUsecase interfaces.WebhookUsecase
+}
+
+func NewWebhookHandler(webhookUsecase interfaces.WebhookUsecase) interfaces.WebhookHandler {
+	return &webhookHandler{webhookUsecase}
+}
+
+// writeWebhook writes webhook without its secret, which is write-only.
+func writeWebhook(w http.ResponseWriter, status int, webhook *models.Webhook) {
+	webhook.Secret = ""
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// CreateWebhook handles POST /webhooks, registering a URL for the book
+// events listed in event_types, or all of them when it is empty.
+func (h *webhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var webhook models.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookUsecase.AddWebhook(r.Context(), &webhook); err != nil {
+		WriteError(w, err)
+		return
+	}
+	writeWebhook(w, http.StatusCreated, &webhook)
+}
+
+func (h *webhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookUsecase.ListWebhooks(r.Context())
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if webhooks == nil {
+		webhooks = []*models.Webhook{}
+	}
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhooks)
+}
+
+func (h *webhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.webhookUsecase.GetWebhook(r.Context(), id)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	writeWebhook(w, http.StatusOK, webhook)
+}
+
+func (h *webhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookUsecase.DeleteWebhook(r.Context(), id); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EnableWebhook handles POST /webhooks/{id}:enable, re-enabling a webhook
+// that was disabled after repeated failures.
+func (h *webhookHandler) EnableWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.webhookUsecase.EnableWebhook(r.Context(), id)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	writeWebhook(w, http.StatusOK, webhook)
+}
+
+// GetDeliveries handles GET /webhooks/{id}/deliveries?limit=..., returning
+// the latest deliveries newest first with the outcome of their latest
+// attempt.
+func (h *webhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var limit int
+	if s := r.URL.Query().Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil {
+			http.Error(w, "limit must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	deliveries, err := h.webhookUsecase.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if deliveries == nil {
+		deliveries = []*models.WebhookDelivery{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
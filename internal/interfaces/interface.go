@@ -1,34 +1,81 @@
 package interfaces
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/Dias221467/MicroServices/internal/domain/models"
 )
 
-// BookRepository defines the methods that any type of book repository must implement.
-type BookRepository interface {
-	Create(book *models.Book) error
-	FindAll() ([]*models.Book, error)
-	FindByID(id int) (*models.Book, error)
-	Update(book *models.Book) error
-	Delete(id int) error
-}
-
 // BookUsecase defines the methods that any type of book usecase must implement.
 type BookUsecase interface {
-	AddBook(book *models.Book) error
-	GetBooks() ([]*models.Book, error)
-	GetBookByID(id int) (*models.Book, error)
-	UpdateBook(book *models.Book) error
-	DeleteBook(id int) error
+	AddBook(ctx context.Context, book *models.Book) error
+	GetBooks(ctx context.Context) ([]*models.Book, error)
+	ListBooks(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error)
+	SearchBooks(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error)
+	GetBookByID(ctx context.Context, id int) (*models.Book, error)
+	UpdateBook(ctx context.Context, book *models.Book) error
+	PatchBook(ctx context.Context, book *models.Book, fields []models.BookField) error
+	DeleteBook(ctx context.Context, id, version int) error
+	ListDeletedBooks(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error)
+	RestoreBook(ctx context.Context, id int) (*models.Book, error)
+	GetBookHistory(ctx context.Context, id int) ([]*models.BookChange, error)
+	GetBookAsOf(ctx context.Context, id int, at time.Time) (*models.Book, error)
+	ListChanges(ctx context.Context, afterID, limit int) ([]*models.BookEvent, error)
+}
+
+// AuthorUsecase defines the methods that any type of author usecase must implement.
+type AuthorUsecase interface {
+	AddAuthor(ctx context.Context, author *models.Author) error
+	ListAuthors(ctx context.Context, query models.ListAuthorsQuery) (*models.AuthorPage, error)
+	GetAuthorByID(ctx context.Context, id int) (*models.Author, error)
+	UpdateAuthor(ctx context.Context, author *models.Author) error
+	DeleteAuthor(ctx context.Context, id int) error
+	ListAuthorBooks(ctx context.Context, id int, query models.ListBooksQuery) (*models.BookPage, error)
+}
+
+// WebhookUsecase defines the methods that any type of webhook usecase must implement.
+type WebhookUsecase interface {
+	AddWebhook(ctx context.Context, webhook *models.Webhook) error
+	ListWebhooks(ctx context.Context) ([]*models.Webhook, error)
+	GetWebhook(ctx context.Context, id int) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	EnableWebhook(ctx context.Context, id int) (*models.Webhook, error)
+	ListDeliveries(ctx context.Context, webhookID, limit int) ([]*models.WebhookDelivery, error)
 }
 
 // BookHandler defines the methods that any type of book handler must implement.
 type BookHandler interface {
 	CreateBook(w http.ResponseWriter, r *http.Request)
 	GetBooks(w http.ResponseWriter, r *http.Request)
+	SearchBooks(w http.ResponseWriter, r *http.Request)
 	GetBook(w http.ResponseWriter, r *http.Request)
 	UpdateBook(w http.ResponseWriter, r *http.Request)
+	PatchBook(w http.ResponseWriter, r *http.Request)
 	DeleteBook(w http.ResponseWriter, r *http.Request)
+	GetDeletedBooks(w http.ResponseWriter, r *http.Request)
+	RestoreBook(w http.ResponseWriter, r *http.Request)
+	GetBookHistory(w http.ResponseWriter, r *http.Request)
+	StreamChanges(w http.ResponseWriter, r *http.Request)
+}
+
+// AuthorHandler defines the methods that any type of author handler must implement.
+type AuthorHandler interface {
+	CreateAuthor(w http.ResponseWriter, r *http.Request)
+	GetAuthors(w http.ResponseWriter, r *http.Request)
+	GetAuthor(w http.ResponseWriter, r *http.Request)
+	UpdateAuthor(w http.ResponseWriter, r *http.Request)
+	DeleteAuthor(w http.ResponseWriter, r *http.Request)
+	GetAuthorBooks(w http.ResponseWriter, r *http.Request)
+}
+
+// WebhookHandler defines the methods that any type of webhook handler must implement.
+type WebhookHandler interface {
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhooks(w http.ResponseWriter, r *http.Request)
+	GetWebhook(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	EnableWebhook(w http.ResponseWriter, r *http.Request)
+	GetDeliveries(w http.ResponseWriter, r *http.Request)
 }
@@ -0,0 +1,105 @@
+// Package router builds the HTTP surface of the service, so that the server
+// and the tests exercise the same routes and middleware.
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// Option configures the router built by New.
+type Option func(*config)
+
+type config struct {
+	middleware []mux.MiddlewareFunc
+	endpoints  []endpoint
+	authors    interfaces.AuthorHandler
+	webhooks   interfaces.WebhookHandler
+}
+
+type endpoint struct {
+	path    string
+	handler http.Handler
+}
+
+// WithAuthorHandler serves the /authors routes with h.
+func WithAuthorHandler(h interfaces.AuthorHandler) Option {
+	return func(c *config) {
+		c.authors = h
+	}
+}
+
+// WithWebhookHandler serves the /webhooks routes with h.
+func WithWebhookHandler(h interfaces.WebhookHandler) Option {
+	return func(c *config) {
+		c.webhooks = h
+	}
+}
+
+// WithMiddleware wraps the book, author and webhook routes in mw, outermost first.
+func WithMiddleware(mw ...mux.MiddlewareFunc) Option {
+	return func(c *config) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithEndpoint serves GET requests for path with h, outside the API
+// middleware. It is meant for operational endpoints such as probes and
+// metrics, which shouldn't show up in request logs and metrics.
+func WithEndpoint(path string, h http.Handler) Option {
+	return func(c *config) {
+		c.endpoints = append(c.endpoints, endpoint{path: path, handler: h})
+	}
+}
+
+// New returns a router serving the book API through h.
+func New(h interfaces.BookHandler, opts ...Option) *mux.Router {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	r := mux.NewRouter()
+	for _, e := range c.endpoints {
+		r.Handle(e.path, e.handler).Methods(http.MethodGet)
+	}
+
+	api := r.PathPrefix("/books").Subrouter()
+	api.HandleFunc("", h.CreateBook).Methods(http.MethodPost)
+	api.HandleFunc("", h.GetBooks).Methods(http.MethodGet)
+	api.HandleFunc("/search", h.SearchBooks).Methods(http.MethodGet)
+	api.HandleFunc("/trash", h.GetDeletedBooks).Methods(http.MethodGet)
+	api.HandleFunc("/changes", h.StreamChanges).Methods(http.MethodGet)
+	api.HandleFunc("/{id}:restore", h.RestoreBook).Methods(http.MethodPost)
+	api.HandleFunc("/{id}/history", h.GetBookHistory).Methods(http.MethodGet)
+	api.HandleFunc("/{id}", h.GetBook).Methods(http.MethodGet)
+	api.HandleFunc("/{id}", h.UpdateBook).Methods(http.MethodPut)
+	api.HandleFunc("/{id}", h.PatchBook).Methods(http.MethodPatch)
+	api.HandleFunc("/{id}", h.DeleteBook).Methods(http.MethodDelete)
+	api.Use(c.middleware...)
+
+	if h := c.authors; h != nil {
+		authors := r.PathPrefix("/authors").Subrouter()
+		authors.HandleFunc("", h.CreateAuthor).Methods(http.MethodPost)
+		authors.HandleFunc("", h.GetAuthors).Methods(http.MethodGet)
+		authors.HandleFunc("/{id}", h.GetAuthor).Methods(http.MethodGet)
+		authors.HandleFunc("/{id}", h.UpdateAuthor).Methods(http.MethodPut)
+		authors.HandleFunc("/{id}", h.DeleteAuthor).Methods(http.MethodDelete)
+		authors.HandleFunc("/{id}/books", h.GetAuthorBooks).Methods(http.MethodGet)
+		authors.Use(c.middleware...)
+	}
+
+	if h := c.webhooks; h != nil {
+		webhooks := r.PathPrefix("/webhooks").Subrouter()
+		webhooks.HandleFunc("", h.CreateWebhook).Methods(http.MethodPost)
+		webhooks.HandleFunc("", h.GetWebhooks).Methods(http.MethodGet)
+		webhooks.HandleFunc("/{id}:enable", h.EnableWebhook).Methods(http.MethodPost)
+		webhooks.HandleFunc("/{id}/deliveries", h.GetDeliveries).Methods(http.MethodGet)
+		webhooks.HandleFunc("/{id}", h.GetWebhook).Methods(http.MethodGet)
+		webhooks.HandleFunc("/{id}", h.DeleteWebhook).Methods(http.MethodDelete)
+		webhooks.Use(c.middleware...)
+	}
+	return r
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type authorServer struct {
+	pb.UnimplementedAuthorServiceServer
+	authorUsecase interfaces.AuthorUsecase
+}
+
+// NewAuthorServiceServer returns a gRPC AuthorService backed by the given usecase.
+func NewAuthorServiceServer(authorUsecase interfaces.AuthorUsecase) pb.AuthorServiceServer {
+	return &authorServer{authorUsecase: authorUsecase}
+}
+
+func (s *authorServer) CreateAuthor(ctx context.Context, req *pb.Author) (*pb.Author, error) {
+	author := &models.Author{Name: req.GetName()}
+	if err := s.authorUsecase.AddAuthor(ctx, author); err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoAuthor(author), nil
+}
+
+func (s *authorServer) ListAuthors(ctx context.Context, req *pb.ListAuthorsRequest) (*pb.ListAuthorsResponse, error) {
+	page, err := s.authorUsecase.ListAuthors(ctx, models.ListAuthorsQuery{
+		NameContains: req.GetNameContains(),
+		Limit:        int(req.GetLimit()),
+		PageToken:    req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListAuthorsResponse{
+		Authors:       make([]*pb.Author, 0, len(page.Authors)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, author := range page.Authors {
+		resp.Authors = append(resp.Authors, toProtoAuthor(author))
+	}
+	return resp, nil
+}
+
+func (s *authorServer) GetAuthor(ctx context.Context, req *pb.AuthorId) (*pb.Author, error) {
+	author, err := s.authorUsecase.GetAuthorByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoAuthor(author), nil
+}
+
+func (s *authorServer) UpdateAuthor(ctx context.Context, req *pb.Author) (*pb.Author, error) {
+	author := &models.Author{ID: int(req.GetId()), Name: req.GetName()}
+	if err := s.authorUsecase.UpdateAuthor(ctx, author); err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoAuthor(author), nil
+}
+
+func (s *authorServer) DeleteAuthor(ctx context.Context, req *pb.AuthorId) (*emptypb.Empty, error) {
+	if err := s.authorUsecase.DeleteAuthor(ctx, int(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *authorServer) ListAuthorBooks(ctx context.Context, req *pb.ListAuthorBooksRequest) (*pb.ListBooksResponse, error) {
+	page, err := s.authorUsecase.ListAuthorBooks(ctx, int(req.GetAuthorId()), fromProtoListRequest(req.GetBooks()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoBookPage(page), nil
+}
@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type bookServer struct {
+	pb.UnimplementedBookServiceServer
+	bookUsecase interfaces.BookUsecase
+}
+
+// NewBookServiceServer returns a gRPC BookService backed by the given usecase.
+func NewBookServiceServer(bookUsecase interfaces.BookUsecase) pb.BookServiceServer {
+	return &bookServer{bookUsecase: bookUsecase}
+}
+
+func (s *bookServer) CreateBook(ctx context.Context, req *pb.Book) (*pb.Book, error) {
+	book := fromProtoBook(req)
+	book.ID = 0
+	if err := s.bookUsecase.AddBook(ctx, book); err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoBook(book), nil
+}
+
+func (s *bookServer) GetBooks(ctx context.Context, _ *emptypb.Empty) (*pb.BookList, error) {
+	books, err := s.bookUsecase.GetBooks(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	list := &pb.BookList{Books: make([]*pb.Book, 0, len(books))}
+	for _, book := range books {
+		list.Books = append(list.Books, toProtoBook(book))
+	}
+	return list, nil
+}
+
+func (s *bookServer) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+	page, err := s.bookUsecase.ListBooks(ctx, fromProtoListRequest(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoBookPage(page), nil
+}
+
+func (s *bookServer) SearchBooks(ctx context.Context, req *pb.SearchBooksRequest) (*pb.SearchBooksResponse, error) {
+	results, err := s.bookUsecase.SearchBooks(ctx, models.SearchQuery{Query: req.GetQuery(), Limit: int(req.GetLimit())})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.SearchBooksResponse{Results: make([]*pb.SearchResult, 0, len(results))}
+	for _, res := range results {
+		resp.Results = append(resp.Results, &pb.SearchResult{
+			Book:            toProtoBook(res.Book),
+			Score:           res.Score,
+			TitleHighlight:  res.Highlights.Title,
+			AuthorHighlight: res.Highlights.Author,
+		})
+	}
+	return resp, nil
+}
+
+// GetBook returns a book, or the book as of BookId.as_of when that is set.
+func (s *bookServer) GetBook(ctx context.Context, req *pb.BookId) (*pb.Book, error) {
+	var (
+		book *models.Book
+		err  error
+	)
+	if req.GetAsOf() != nil {
+		book, err = s.bookUsecase.GetBookAsOf(ctx, int(req.GetId()), req.GetAsOf().AsTime())
+	} else {
+		book, err = s.bookUsecase.GetBookByID(ctx, int(req.GetId()))
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoBook(book), nil
+}
+
+// UpdateBook writes the fields in the update mask, or replaces the book when
+// the mask is empty. It requires book.version, like If-Match over HTTP.
+func (s *bookServer) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.Book, error) {
+	if req.GetBook().GetVersion() == 0 {
+		return nil, errVersionRequired
+	}
+	book := fromProtoBook(req.GetBook())
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if err := s.bookUsecase.UpdateBook(ctx, book); err != nil {
+			return nil, toStatus(err)
+		}
+		return toProtoBook(book), nil
+	}
+
+	fields := make([]models.BookField, len(paths))
+	for i, path := range paths {
+		fields[i] = models.BookField(path)
+	}
+	if err := s.bookUsecase.PatchBook(ctx, book, fields); err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoBook(book), nil
+}
+
+func (s *bookServer) DeleteBook(ctx context.Context, req *pb.BookId) (*emptypb.Empty, error) {
+	if req.GetVersion() == 0 {
+		return nil, errVersionRequired
+	}
+	if err := s.bookUsecase.DeleteBook(ctx, int(req.GetId()), int(req.GetVersion())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *bookServer) ListDeletedBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+	page, err := s.bookUsecase.ListDeletedBooks(ctx, fromProtoListRequest(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoBookPage(page), nil
+}
+
+// RestoreBook takes a book out of the trash; BookId.version is ignored.
+func (s *bookServer) RestoreBook(ctx context.Context, req *pb.BookId) (*pb.Book, error) {
+	book, err := s.bookUsecase.RestoreBook(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoBook(book), nil
+}
+
+func (s *bookServer) GetBookHistory(ctx context.Context, req *pb.GetBookHistoryRequest) (*pb.GetBookHistoryResponse, error) {
+	changes, err := s.bookUsecase.GetBookHistory(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.GetBookHistoryResponse{Changes: make([]*pb.BookChange, 0, len(changes))}
+	for _, change := range changes {
+		pbChange, err := toProtoBookChange(change)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		resp.Changes = append(resp.Changes, pbChange)
+	}
+	return resp, nil
+}
@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toProtoBook converts a domain book into its protobuf representation.
+func toProtoBook(book *models.Book) *pb.Book {
+	if book == nil {
+		return nil
+	}
+	pbBook := &pb.Book{
+		Id:           int32(book.ID),
+		Title:        book.Title,
+		Author:       book.Author,
+		Year:         int32(book.BookYear),
+		Isbn:         book.ISBN,
+		Publisher:    book.Publisher,
+		Language:     book.Language,
+		Pages:        int32(book.Pages),
+		Description:  book.Description,
+		Edition:      book.Edition,
+		CreatedAt:    toProtoTime(book.CreatedAt),
+		UpdatedAt:    toProtoTime(book.UpdatedAt),
+		Contributors: toProtoContributors(book.Contributors),
+		Version:      int32(book.Version),
+	}
+	if book.DeletedAt != nil {
+		pbBook.DeletedAt = timestamppb.New(*book.DeletedAt)
+	}
+	return pbBook
+}
+
+func toProtoContributors(contributors []models.Contributor) []*pb.Contributor {
+	if len(contributors) == 0 {
+		return nil
+	}
+	out := make([]*pb.Contributor, len(contributors))
+	for i, c := range contributors {
+		out[i] = &pb.Contributor{AuthorId: int32(c.AuthorID), Name: c.Name, Role: string(c.Role)}
+	}
+	return out
+}
+
+func fromProtoContributors(contributors []*pb.Contributor) []models.Contributor {
+	if len(contributors) == 0 {
+		return nil
+	}
+	out := make([]models.Contributor, len(contributors))
+	for i, c := range contributors {
+		out[i] = models.Contributor{AuthorID: int(c.GetAuthorId()), Name: c.GetName(), Role: models.Role(c.GetRole())}
+	}
+	return out
+}
+
+// fromProtoBook converts a protobuf book into the domain model.
+func fromProtoBook(book *pb.Book) *models.Book {
+	return &models.Book{
+		ID:           int(book.GetId()),
+		Title:        book.GetTitle(),
+		Author:       book.GetAuthor(),
+		BookYear:     int(book.GetYear()),
+		ISBN:         book.GetIsbn(),
+		Publisher:    book.GetPublisher(),
+		Language:     book.GetLanguage(),
+		Pages:        int(book.GetPages()),
+		Description:  book.GetDescription(),
+		Edition:      book.GetEdition(),
+		Contributors: fromProtoContributors(book.GetContributors()),
+		Version:      int(book.GetVersion()),
+	}
+}
+
+// fromProtoListRequest converts a listing request into the domain query.
+func fromProtoListRequest(req *pb.ListBooksRequest) models.ListBooksQuery {
+	return models.ListBooksQuery{
+		SortBy:    models.SortField(req.GetSortBy()),
+		Desc:      req.GetDescending(),
+		Limit:     int(req.GetLimit()),
+		PageToken: req.GetPageToken(),
+		Filter: models.BookFilter{
+			Author:        req.GetAuthor(),
+			AuthorID:      int(req.GetAuthorId()),
+			YearFrom:      int(req.GetYearFrom()),
+			YearTo:        int(req.GetYearTo()),
+			TitleContains: req.GetTitleContains(),
+			ISBN:          req.GetIsbn(),
+		},
+	}
+}
+
+func toProtoBookPage(page *models.BookPage) *pb.ListBooksResponse {
+	resp := &pb.ListBooksResponse{
+		Books:         make([]*pb.Book, 0, len(page.Books)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, book := range page.Books {
+		resp.Books = append(resp.Books, toProtoBook(book))
+	}
+	return resp
+}
+
+func toProtoBookChange(change *models.BookChange) (*pb.BookChange, error) {
+	pbChange := &pb.BookChange{
+		Id:        int64(change.ID),
+		BookId:    int32(change.BookID),
+		Version:   int32(change.Version),
+		Operation: string(change.Operation),
+		Actor:     change.Actor,
+		ChangedAt: toProtoTime(change.ChangedAt),
+		Changes:   make([]*pb.FieldChange, 0, len(change.Changes)),
+	}
+	for _, c := range change.Changes {
+		fc := &pb.FieldChange{Field: string(c.Field)}
+		var err error
+		if fc.Before, err = toProtoValue(c.Before); err != nil {
+			return nil, err
+		}
+		if fc.After, err = toProtoValue(c.After); err != nil {
+			return nil, err
+		}
+		pbChange.Changes = append(pbChange.Changes, fc)
+	}
+	return pbChange, nil
+}
+
+// toProtoValue converts a JSON value, leaving absent ones unset.
+func toProtoValue(raw []byte) (*structpb.Value, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var v structpb.Value
+	if err := protojson.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func toProtoAuthor(author *models.Author) *pb.Author {
+	return &pb.Author{
+		Id:        int32(author.ID),
+		Name:      author.Name,
+		CreatedAt: toProtoTime(author.CreatedAt),
+		UpdatedAt: toProtoTime(author.UpdatedAt),
+	}
+}
+
+// toProtoTime leaves unset times unset rather than sending the zero time.
+func toProtoTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
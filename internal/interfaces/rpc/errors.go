@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errVersionRequired is the gRPC counterpart of 428 Precondition Required.
+var errVersionRequired = status.Error(codes.FailedPrecondition, "version is required; use the version from GetBook")
+
+// toStatus maps a domain error onto a gRPC status error. As with the HTTP
+// handlers, the status carries only the domain error's own message and the
+// full error, which may wrap a driver error, is logged instead.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var code codes.Code
+	switch errs.KindOf(err) {
+	case errs.KindNotFound:
+		code = codes.NotFound
+	case errs.KindConflict:
+		code = codes.AlreadyExists
+	case errs.KindValidation:
+		code = codes.InvalidArgument
+	case errs.KindUnavailable:
+		code = codes.Unavailable
+	case errs.KindPrecondition:
+		code = codes.FailedPrecondition
+	default:
+		code = codes.Internal
+	}
+	msg := code.String()
+	if code != codes.Internal {
+		msg = errs.MessageOf(err)
+	}
+	if msg != err.Error() {
+		slog.Warn("rpc failed", slog.String("code", code.String()), slog.String("error", err.Error()))
+	}
+	return status.Error(code, msg)
+}
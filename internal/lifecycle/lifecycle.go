@@ -0,0 +1,181 @@
+// Package lifecycle tracks whether the service should receive traffic and
+// shuts its servers and background work down gracefully.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/config"
+	"google.golang.org/grpc"
+)
+
+// Lifecycle tracks whether the service should receive traffic and the
+// cleanup to run once it stops serving.
+type Lifecycle struct {
+	ready atomic.Bool
+
+	mu       sync.Mutex
+	hooks    []shutdownHook
+	notReady []func()
+
+	logger *slog.Logger
+	sleep  func(time.Duration)
+	after  func(time.Duration) <-chan time.Time
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Option configures a Lifecycle.
+type Option func(*Lifecycle)
+
+// WithLogger sets the logger shutdown progress is reported to. It defaults
+// to slog.Default().
+func WithLogger(l *slog.Logger) Option {
+	return func(lc *Lifecycle) { lc.logger = l }
+}
+
+// WithClock replaces time.Sleep, which waits out the drain delay, and
+// time.After, which ends the drain, so that tests can control both.
+func WithClock(sleep func(time.Duration), after func(time.Duration) <-chan time.Time) Option {
+	return func(lc *Lifecycle) { lc.sleep, lc.after = sleep, after }
+}
+
+// New returns a Lifecycle that is not ready until SetReady is called.
+func New(opts ...Option) *Lifecycle {
+	lc := &Lifecycle{
+		logger: slog.Default(),
+		sleep:  time.Sleep,
+		after:  time.After,
+	}
+	for _, opt := range opts {
+		opt(lc)
+	}
+	return lc
+}
+
+// SetReady marks the service ready once its servers have started.
+func (l *Lifecycle) SetReady() {
+	l.ready.Store(true)
+}
+
+// OnShutdown registers fn to run after both servers have drained. Hooks run in
+// reverse registration order, like defers, so the DB pool registered first is
+// closed after the background work that uses it has been flushed.
+func (l *Lifecycle) OnShutdown(name string, fn func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// OnNotReady registers fn to run as soon as shutdown begins, before the drain
+// delay, alongside readiness turning false.
+func (l *Lifecycle) OnNotReady(fn func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.notReady = append(l.notReady, fn)
+}
+
+// ReadinessCheck fails until the servers have started and again once shutdown
+// has begun.
+func (l *Lifecycle) ReadinessCheck(context.Context) error {
+	if !l.ready.Load() {
+		return errors.New("not serving")
+	}
+	return nil
+}
+
+// RunHooks runs the shutdown hooks registered so far, once each, and joins
+// their errors. Shutdown calls it; it is exported for startup failures, which
+// have no servers to drain.
+func (l *Lifecycle) RunHooks(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := l.hooks
+	l.hooks = nil
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		l.logger.Info("shutting down", "component", hooks[i].name)
+		if err := hooks[i].fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", hooks[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown flips readiness, gives load balancers cfg.DrainDelay to notice,
+// drains both servers within cfg.Timeout and then runs the shutdown hooks.
+func (l *Lifecycle) Shutdown(cfg config.ShutdownConfig, httpServer *http.Server, grpcServer *grpc.Server) error {
+	l.ready.Store(false)
+	l.mu.Lock()
+	notReady := l.notReady
+	l.mu.Unlock()
+	for _, fn := range notReady {
+		fn()
+	}
+	if cfg.DrainDelay > 0 {
+		l.logger.Info("marked not ready, waiting before closing listeners", "drain_delay", cfg.DrainDelay)
+		l.sleep(cfg.DrainDelay)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	deadline := l.after(cfg.Timeout)
+	go func() {
+		select {
+		case <-deadline:
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+
+	var (
+		wg      sync.WaitGroup
+		httpErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			httpServer.Close()
+			if ctx.Err() != nil {
+				err = context.Cause(ctx)
+			}
+			httpErr = fmt.Errorf("draining HTTP server: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		l.stopGRPC(ctx, grpcServer)
+	}()
+	wg.Wait()
+
+	return errors.Join(httpErr, l.RunHooks(ctx))
+}
+
+// stopGRPC waits for in-flight RPCs to finish, cancelling them if ctx expires first.
+func (l *Lifecycle) stopGRPC(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		l.logger.Warn("gRPC drain deadline exceeded, closing remaining streams")
+		srv.Stop()
+		<-done
+	}
+}
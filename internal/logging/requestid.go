@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const (
+	// RequestIDHeader carries the request ID over HTTP.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDMetadata carries the request ID in gRPC metadata.
+	RequestIDMetadata = "x-request-id"
+
+	maxRequestIDLen = 128
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewRequestID returns a random 128-bit ID in hex.
+func NewRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// requestIDOrNew returns id when a caller supplied a usable one, or a fresh
+// ID otherwise. IDs are restricted to printable ASCII so that clients can't
+// inject arbitrary content into logs and response headers.
+func requestIDOrNew(id string) string {
+	if id == "" || len(id) > maxRequestIDLen {
+		return NewRequestID()
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return NewRequestID()
+		}
+	}
+	return id
+}
@@ -0,0 +1,194 @@
+// Package metrics exposes Prometheus metrics for the HTTP and gRPC servers,
+// the database pool, the book usecase, the outbox relay and webhook
+// deliveries.
+package metrics
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "books"
+
+// Metrics owns a registry and the collectors recorded into it.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight prometheus.Gauge
+
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+
+	booksCreated  prometheus.Counter
+	booksUpdated  prometheus.Counter
+	booksDeleted  prometheus.Counter
+	booksRestored prometheus.Counter
+	booksPurged   prometheus.Counter
+	failures      *prometheus.CounterVec
+
+	eventsPublished *prometheus.CounterVec
+	eventFailures   *prometheus.CounterVec
+
+	webhookDeliveries *prometheus.CounterVec
+	webhookFailures   *prometheus.CounterVec
+	webhooksDisabled  prometheus.Counter
+}
+
+// New returns Metrics registered on a fresh registry, together with the
+// standard Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests handled, by route template, method and status code.",
+		}, []string{"route", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency, by route template, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "code"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "HTTP requests currently being served.",
+		}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "RPCs handled, by service, method and status code.",
+		}, []string{"service", "method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "RPC latency, by service, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "method", "code"}),
+		booksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "created_total",
+			Help:      "Books created.",
+		}),
+		booksUpdated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "updated_total",
+			Help:      "Books updated.",
+		}),
+		booksDeleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deleted_total",
+			Help:      "Books moved to the trash.",
+		}),
+		booksRestored: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "restored_total",
+			Help:      "Books restored from the trash.",
+		}),
+		booksPurged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "purged_total",
+			Help:      "Books purged from the trash for good.",
+		}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usecase",
+			Name:      "failures_total",
+			Help:      "Failed usecase operations, by operation and error kind.",
+		}, []string{"operation", "kind"}),
+		eventsPublished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "events_published_total",
+			Help:      "Book events published from the outbox, by event type.",
+		}, []string{"type"}),
+		eventFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "publish_failures_total",
+			Help:      "Failed attempts to publish book events, by event type.",
+		}, []string{"type"}),
+		webhookDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "webhook",
+			Name:      "deliveries_total",
+			Help:      "Book events delivered to webhooks, by event type.",
+		}, []string{"type"}),
+		webhookFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "webhook",
+			Name:      "delivery_failures_total",
+			Help:      "Failed attempts to deliver book events to webhooks, by event type.",
+		}, []string{"type"}),
+		webhooksDisabled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "webhook",
+			Name:      "disabled_total",
+			Help:      "Webhooks disabled after repeated delivery failures.",
+		}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.httpInFlight,
+		m.grpcRequests, m.grpcDuration,
+		m.booksCreated, m.booksUpdated, m.booksDeleted, m.booksRestored, m.booksPurged, m.failures,
+		m.eventsPublished, m.eventFailures,
+		m.webhookDeliveries, m.webhookFailures, m.webhooksDisabled,
+	)
+	return m
+}
+
+// Registry returns the registry the metrics are recorded into.
+func (m *Metrics) Registry() *prometheus.Registry { return m.registry }
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) BookCreated()  { m.booksCreated.Inc() }
+func (m *Metrics) BookUpdated()  { m.booksUpdated.Inc() }
+func (m *Metrics) BookDeleted()  { m.booksDeleted.Inc() }
+func (m *Metrics) BookRestored() { m.booksRestored.Inc() }
+
+// BooksPurged counts n books purged from the trash.
+func (m *Metrics) BooksPurged(n int) { m.booksPurged.Add(float64(n)) }
+
+// OperationFailed counts a failed usecase operation by the kind of err.
+func (m *Metrics) OperationFailed(operation string, err error) {
+	kind := strings.ReplaceAll(errs.KindOf(err).String(), " ", "_")
+	m.failures.WithLabelValues(operation, kind).Inc()
+}
+
+func (m *Metrics) EventPublished(eventType models.BookEventType) {
+	m.eventsPublished.WithLabelValues(string(eventType)).Inc()
+}
+
+func (m *Metrics) EventFailed(eventType models.BookEventType) {
+	m.eventFailures.WithLabelValues(string(eventType)).Inc()
+}
+
+func (m *Metrics) WebhookDelivered(eventType models.BookEventType) {
+	m.webhookDeliveries.WithLabelValues(string(eventType)).Inc()
+}
+
+func (m *Metrics) WebhookFailed(eventType models.BookEventType) {
+	m.webhookFailures.WithLabelValues(string(eventType)).Inc()
+}
+
+func (m *Metrics) WebhookDisabled() { m.webhooksDisabled.Inc() }
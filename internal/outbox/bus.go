@@ -0,0 +1,55 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// Handler consumes the events published on a Bus.
+type Handler func(ctx context.Context, event *models.BookEvent) error
+
+// Bus is an in-process Sink that hands every event to each of its
+// subscribers in turn. If any of them fails, the event is retried for all
+// of them, so handlers must tolerate duplicates.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[int]Handler
+	nextID   int
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[int]Handler)}
+}
+
+// Subscribe adds h to the bus until the returned function is called.
+func (b *Bus) Subscribe(h Handler) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = h
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, event *models.BookEvent) error {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, h := range b.handlers {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		errs = append(errs, h(ctx, event))
+	}
+	return errors.Join(errs...)
+}
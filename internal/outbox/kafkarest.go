@@ -0,0 +1,94 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+const (
+	kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+	kafkaV2ContentType   = "application/vnd.kafka.v2+json"
+)
+
+// KafkaRESTSink publishes events to a Kafka topic through the v2 API of a
+// Kafka REST Proxy. Events are keyed by book ID, so that the events of a
+// book land in one partition and keep their order.
+type KafkaRESTSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewKafkaRESTSink returns a sink producing to topic through the proxy at
+// baseURL. A nil client means one with a ten second timeout.
+func NewKafkaRESTSink(baseURL, topic string, client *http.Client) *KafkaRESTSink {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &KafkaRESTSink{
+		endpoint: strings.TrimSuffix(baseURL, "/") + "/topics/" + url.PathEscape(topic),
+		client:   client,
+	}
+}
+
+type kafkaRecord struct {
+	Key   string            `json:"key"`
+	Value *models.BookEvent `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (s *KafkaRESTSink) Publish(ctx context.Context, event *models.BookEvent) error {
+	body, err := json.Marshal(map[string][]kafkaRecord{
+		"records": {{Key: strconv.Itoa(event.BookID), Value: event}},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaJSONContentType)
+	req.Header.Set("Accept", kafkaV2ContentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("kafka rest proxy: %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	// The proxy reports failures of single records with a 200.
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(data, &produced); err != nil {
+		return fmt.Errorf("kafka rest proxy: decoding response: %w", err)
+	}
+	if len(produced.Offsets) != 1 {
+		return fmt.Errorf("kafka rest proxy: got %d offsets for 1 record", len(produced.Offsets))
+	}
+	if o := produced.Offsets[0]; o.ErrorCode != nil || o.Error != "" {
+		return fmt.Errorf("kafka rest proxy: producing record: %s", o.Error)
+	}
+	return nil
+}
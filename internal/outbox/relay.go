@@ -0,0 +1,173 @@
+// Package outbox publishes the book events that the repositories write to
+// the outbox, at least once, to a pluggable Sink.
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/poll"
+)
+
+// Sink is where the relay publishes events. Publish must not return nil
+// until the event is durably handed over: the relay then never offers it
+// again. Any error is retried with backoff.
+type Sink interface {
+	Publish(ctx context.Context, event *models.BookEvent) error
+}
+
+// Metrics receives the outcome of every publish attempt; *metrics.Metrics
+// implements it.
+type Metrics interface {
+	EventPublished(eventType models.BookEventType)
+	EventFailed(eventType models.BookEventType)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) EventPublished(models.BookEventType) {}
+func (nopMetrics) EventFailed(models.BookEventType)    {}
+
+// Relay moves events from an outbox to a sink.
+type Relay struct {
+	store repository.OutboxRepository
+	sink  Sink
+
+	batchSize int
+	lease     time.Duration
+	backoff   poll.Backoff
+	retention time.Duration
+	logger    *slog.Logger
+	metrics   Metrics
+}
+
+// Option configures a Relay.
+type Option func(*Relay)
+
+// WithBatchSize sets how many events are claimed at a time. It defaults to 100.
+func WithBatchSize(n int) Option {
+	return func(r *Relay) { r.batchSize = n }
+}
+
+// WithLease sets how long a claimed batch has to be published before its
+// events are offered again. It defaults to 30 seconds.
+func WithLease(d time.Duration) Option {
+	return func(r *Relay) { r.lease = d }
+}
+
+// WithBackoff sets the delay before retrying an event after its first
+// failed attempt, doubling with every further failure up to limit. It
+// defaults to one second, up to five minutes.
+func WithBackoff(initial, limit time.Duration) Option {
+	return func(r *Relay) { r.backoff = poll.Backoff{Initial: initial, Max: limit} }
+}
+
+// WithRetention makes Run delete published events once they are older than
+// d. Zero, the default, keeps them.
+func WithRetention(d time.Duration) Option {
+	return func(r *Relay) { r.retention = d }
+}
+
+// WithLogger sets the logger used for failed attempts. It defaults to
+// slog.Default().
+func WithLogger(l *slog.Logger) Option {
+	return func(r *Relay) { r.logger = l }
+}
+
+// WithMetrics records publish attempts into m.
+func WithMetrics(m Metrics) Option {
+	return func(r *Relay) { r.metrics = m }
+}
+
+// NewRelay returns a relay from store to sink.
+func NewRelay(store repository.OutboxRepository, sink Sink, opts ...Option) *Relay {
+	r := &Relay{
+		store:     store,
+		sink:      sink,
+		batchSize: 100,
+		lease:     30 * time.Second,
+		backoff:   poll.Backoff{Initial: time.Second, Max: 5 * time.Minute},
+		logger:    slog.Default(),
+		metrics:   nopMetrics{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RelayOnce claims a batch of due events and publishes them in order,
+// returning how many it claimed. Events that fail are scheduled for a retry;
+// the error is only about the outbox itself.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	events, err := r.store.Claim(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	// Publishing stops when the lease runs out, since other relays may
+	// claim the rest of the batch from then on.
+	publishCtx, cancel := context.WithTimeout(ctx, r.lease)
+	defer cancel()
+	for _, event := range events {
+		if publishCtx.Err() != nil {
+			break
+		}
+		if err := r.publish(ctx, publishCtx, event); err != nil {
+			return len(events), err
+		}
+	}
+	return len(events), nil
+}
+
+func (r *Relay) publish(ctx, publishCtx context.Context, event *models.BookEvent) error {
+	err := r.sink.Publish(publishCtx, event)
+	if err == nil {
+		r.metrics.EventPublished(event.Type)
+		return r.store.MarkPublished(ctx, event.ID)
+	}
+	if ctx.Err() != nil {
+		// Shutting down: the lease will offer the event again.
+		return ctx.Err()
+	}
+
+	attempts := event.Attempts + 1
+	retryAt := time.Now().Add(r.backoff.Delay(attempts))
+	r.metrics.EventFailed(event.Type)
+	r.logger.WarnContext(ctx, "publishing event failed",
+		"event_id", event.ID, "event_type", event.Type, "book_id", event.BookID,
+		"attempts", attempts, "retry_at", retryAt, "error", err)
+	return r.store.MarkFailed(ctx, event.ID, err.Error(), retryAt)
+}
+
+// Run relays events every interval, and straight away while full batches
+// keep coming, until ctx is done. Failures are logged and retried on the
+// next tick.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	poll.Loop{
+		Interval:  interval,
+		BatchSize: r.batchSize,
+		Once:      r.RelayOnce,
+		OnError: func(ctx context.Context, err error) {
+			r.logger.ErrorContext(ctx, "relaying outbox events failed", "error", err)
+		},
+		Idle: r.prune,
+	}.Run(ctx)
+}
+
+func (r *Relay) prune(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+	n, err := r.store.DeletePublished(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "deleting published events failed", "error", err)
+		return
+	}
+	if n > 0 {
+		r.logger.DebugContext(ctx, "published events deleted", "count", n)
+	}
+}
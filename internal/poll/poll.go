@@ -0,0 +1,66 @@
+// Package poll holds the batch loop and retry backoff shared by the
+// background workers that drain a table: the outbox relay and the webhook
+// dispatcher.
+package poll
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff is the delay before retrying after a first failed attempt,
+// doubling with every further failure up to Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Delay returns the delay after the given number of failed attempts.
+func (b Backoff) Delay(attempts int) time.Duration {
+	d := b.Initial
+	for i := 1; i < attempts && d < b.Max; i++ {
+		d *= 2
+	}
+	return min(d, b.Max)
+}
+
+// Loop processes batches every Interval, and straight away while full
+// batches keep coming.
+type Loop struct {
+	Interval  time.Duration
+	BatchSize int
+	// Once processes one batch, returning how many items it claimed.
+	Once func(ctx context.Context) (int, error)
+	// OnError is told about a failed batch, which is retried on the next
+	// tick.
+	OnError func(ctx context.Context, err error)
+	// Idle, if set, runs whenever the loop is about to wait for a tick.
+	Idle func(ctx context.Context)
+}
+
+// Run runs the loop until ctx is done.
+func (l Loop) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.Interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := l.Once(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			l.OnError(ctx, err)
+		} else if n == l.BatchSize {
+			continue
+		}
+		if l.Idle != nil {
+			l.Idle(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+)
+
+var _ interfaces.AuthorUsecase = (*AuthorUsecase)(nil)
+
+// AuthorUsecase manages authors. It shares the logger, tracer, metrics and
+// timeouts of the BookUsecase it lists books through.
+type AuthorUsecase struct {
+	AuthorRepo repository.AuthorRepository
+	books      *BookUsecase
+}
+
+func NewAuthorUsecase(authorRepo repository.AuthorRepository, books *BookUsecase) *AuthorUsecase {
+	return &AuthorUsecase{AuthorRepo: authorRepo, books: books}
+}
+
+func (u *AuthorUsecase) AddAuthor(ctx context.Context, author *models.Author) error {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Create)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "AuthorUsecase.AddAuthor")
+	defer span.End()
+
+	if err := validateAuthor(author); err != nil {
+		return b.fail(ctx, "add_author", err)
+	}
+	if err := u.AuthorRepo.Create(ctx, author); err != nil {
+		return b.fail(ctx, "add_author", err)
+	}
+	b.logger.InfoContext(ctx, "author added", "author_id", author.ID)
+	return nil
+}
+
+// ListAuthors returns one page of authors ordered by name.
+func (u *AuthorUsecase) ListAuthors(ctx context.Context, query models.ListAuthorsQuery) (*models.AuthorPage, error) {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.List)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "AuthorUsecase.ListAuthors")
+	defer span.End()
+
+	switch {
+	case query.Limit < 0:
+		return nil, b.fail(ctx, "list_authors", errs.Validation("limit must not be negative"))
+	case query.Limit == 0:
+		query.Limit = DefaultPageSize
+	case query.Limit > MaxPageSize:
+		query.Limit = MaxPageSize
+	}
+	page, err := u.AuthorRepo.List(ctx, query)
+	if err != nil {
+		return nil, b.fail(ctx, "list_authors", err)
+	}
+	b.logger.DebugContext(ctx, "authors listed", "limit", query.Limit, "count", len(page.Authors))
+	return page, nil
+}
+
+func (u *AuthorUsecase) GetAuthorByID(ctx context.Context, id int) (*models.Author, error) {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Get)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "AuthorUsecase.GetAuthorByID")
+	defer span.End()
+
+	author, err := u.AuthorRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, b.fail(ctx, "get_author", err)
+	}
+	return author, nil
+}
+
+// UpdateAuthor renames an author; the author line of its books follows.
+func (u *AuthorUsecase) UpdateAuthor(ctx context.Context, author *models.Author) error {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Update)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "AuthorUsecase.UpdateAuthor")
+	defer span.End()
+
+	if err := validateAuthor(author); err != nil {
+		return b.fail(ctx, "update_author", err)
+	}
+	if err := u.AuthorRepo.Update(ctx, author); err != nil {
+		return b.fail(ctx, "update_author", err)
+	}
+	b.logger.InfoContext(ctx, "author updated", "author_id", author.ID)
+	return nil
+}
+
+// DeleteAuthor removes an author that no book links to any more.
+func (u *AuthorUsecase) DeleteAuthor(ctx context.Context, id int) error {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Delete)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "AuthorUsecase.DeleteAuthor")
+	defer span.End()
+
+	if err := u.AuthorRepo.Delete(ctx, id); err != nil {
+		return b.fail(ctx, "delete_author", err)
+	}
+	b.logger.InfoContext(ctx, "author deleted", "author_id", id)
+	return nil
+}
+
+// ListAuthorBooks returns one page of the books the author contributed to,
+// in any role. It fails with not-found for an unknown author rather than
+// returning an empty page.
+func (u *AuthorUsecase) ListAuthorBooks(ctx context.Context, id int, query models.ListBooksQuery) (*models.BookPage, error) {
+	if _, err := u.GetAuthorByID(ctx, id); err != nil {
+		return nil, err
+	}
+	query.Filter.AuthorID = id
+	return u.books.ListBooks(ctx, query)
+}
+
+func validateAuthor(author *models.Author) error {
+	author.Name = strings.TrimSpace(author.Name)
+	if models.AuthorKey(author.Name) == "" {
+		return errs.Validation("name is required")
+	}
+	return nil
+}
@@ -1,73 +1,359 @@
 package usecases
 
 import (
-	"log"
-	"os"
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
 	"github.com/Dias221467/MicroServices/internal/domain/models"
-	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/postgres"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/text/language"
 )
 
+var _ interfaces.BookUsecase = (*BookUsecase)(nil)
+
+const (
+	// DefaultPageSize is used when a listing doesn't specify a limit.
+	DefaultPageSize = 50
+	// MaxPageSize caps the number of books returned in one page.
+	MaxPageSize = 1000
+
+	// DefaultSearchLimit and MaxSearchLimit bound the number of search results.
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	// MaxDescriptionLength caps a book description, in characters.
+	MaxDescriptionLength = 10000
+)
+
+// tracerName is the instrumentation scope of the usecase spans.
+const tracerName = "github.com/Dias221467/MicroServices/internal/usecases"
+
 type BookUsecase struct {
-	BookRepo *postgres.BookRepository
-	logger   *log.Logger
+	BookRepo repository.BookRepository
+	logger   *slog.Logger
+	timeouts Timeouts
+	metrics  Metrics
+	tracer   trace.Tracer
 }
 
-func NewBookUsecase(bookRepo *postgres.BookRepository) *BookUsecase {
-	return &BookUsecase{
+func NewBookUsecase(bookRepo repository.BookRepository, opts ...Option) *BookUsecase {
+	u := &BookUsecase{
 		BookRepo: bookRepo,
-		logger:   log.New(os.Stdout, "USECASE: ", log.Ldate|log.Ltime|log.Lshortfile),
+		logger:   slog.Default(),
+		metrics:  nopMetrics{},
+		tracer:   otel.Tracer(tracerName),
+	}
+	for _, opt := range opts {
+		opt(u)
 	}
+	return u
 }
 
-func (u *BookUsecase) AddBook(book *models.Book) error {
-	u.logger.Println("Adding book:", book)
-	if err := u.BookRepo.AddBook(book); err != nil {
-		u.logger.Println("Error adding book:", err)
-		return err
+func (u *BookUsecase) AddBook(ctx context.Context, book *models.Book) error {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Create)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.AddBook")
+	defer span.End()
+
+	u.logger.DebugContext(ctx, "adding book", "title", book.Title, "author", book.Author)
+	if err := validateBook(book); err != nil {
+		return u.fail(ctx, "add_book", err)
+	}
+	if err := u.BookRepo.Create(ctx, book); err != nil {
+		return u.fail(ctx, "add_book", err)
 	}
-	u.logger.Println("Book added successfully:", book)
+	u.metrics.BookCreated()
+	u.logger.InfoContext(ctx, "book added", "book_id", book.ID)
 	return nil
 }
 
-func (u *BookUsecase) GetBooks() ([]*models.Book, error) {
-	u.logger.Println("Retrieving books")
-	books, err := u.BookRepo.GetBooks()
+// ListBooks returns one page of books matching query.
+func (u *BookUsecase) ListBooks(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.List)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.ListBooks")
+	defer span.End()
+
+	if err := normalizeListQuery(&query); err != nil {
+		return nil, u.fail(ctx, "list_books", err)
+	}
+	page, err := u.BookRepo.List(ctx, query)
 	if err != nil {
-		u.logger.Println("Error retrieving books:", err)
-		return nil, err
+		return nil, u.fail(ctx, "list_books", err)
+	}
+	u.logger.DebugContext(ctx, "books listed", "sort_by", query.SortBy, "limit", query.Limit, "count", len(page.Books))
+	return page, nil
+}
+
+// SearchBooks returns the books most relevant to a free-text query.
+func (u *BookUsecase) SearchBooks(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Search)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.SearchBooks")
+	defer span.End()
+
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return nil, u.fail(ctx, "search_books", errs.Validation("search query is required"))
+	}
+	switch {
+	case query.Limit < 0:
+		return nil, u.fail(ctx, "search_books", errs.Validation("limit must not be negative"))
+	case query.Limit == 0:
+		query.Limit = DefaultSearchLimit
+	case query.Limit > MaxSearchLimit:
+		query.Limit = MaxSearchLimit
+	}
+
+	results, err := u.BookRepo.Search(ctx, query)
+	if err != nil {
+		return nil, u.fail(ctx, "search_books", err)
 	}
-	u.logger.Println("Books retrieved successfully")
-	return books, nil
+	u.logger.DebugContext(ctx, "books searched", "query", query.Query, "count", len(results))
+	return results, nil
 }
 
-func (u *BookUsecase) GetBookByID(id int) (*models.Book, error) {
-	u.logger.Println("Retrieving book by ID:", id)
-	book, err := u.BookRepo.GetBookByID(id)
+// GetBooks returns the first MaxPageSize books ordered by ID.
+//
+// Deprecated: use ListBooks, which can page through the whole catalogue.
+func (u *BookUsecase) GetBooks(ctx context.Context) ([]*models.Book, error) {
+	page, err := u.ListBooks(ctx, models.ListBooksQuery{Limit: MaxPageSize})
 	if err != nil {
-		u.logger.Println("Error retrieving book by ID:", err)
 		return nil, err
 	}
-	u.logger.Println("Book retrieved successfully:", book)
+	return page.Books, nil
+}
+
+func (u *BookUsecase) GetBookByID(ctx context.Context, id int) (*models.Book, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Get)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.GetBookByID")
+	defer span.End()
+
+	book, err := u.BookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, u.fail(ctx, "get_book", err)
+	}
+	u.logger.DebugContext(ctx, "book retrieved", "book_id", id)
 	return book, nil
 }
 
-func (u *BookUsecase) UpdateBook(book *models.Book) error {
-	u.logger.Println("Updating book:", book)
-	if err := u.BookRepo.UpdateBook(book); err != nil {
-		u.logger.Println("Error updating book:", err)
-		return err
+// UpdateBook replaces a book if it is still at book.Version, or
+// unconditionally when that is zero. On success book.Version is the new version.
+func (u *BookUsecase) UpdateBook(ctx context.Context, book *models.Book) error {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Update)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.UpdateBook")
+	defer span.End()
+
+	if err := validateBook(book); err != nil {
+		return u.fail(ctx, "update_book", err)
+	}
+	if book.Version < 0 {
+		return u.fail(ctx, "update_book", errs.Validation("version must not be negative"))
+	}
+	if err := u.BookRepo.Update(ctx, book); err != nil {
+		return u.fail(ctx, "update_book", err)
+	}
+	u.metrics.BookUpdated()
+	u.logger.InfoContext(ctx, "book updated", "book_id", book.ID)
+	return nil
+}
+
+// PatchBook changes only the given fields of a book, taking their values
+// from book, which must carry the ID and, unless zero, the version the
+// change is based on. Setting the author without contributors replaces the
+// contributors with that single author. On success book holds the whole
+// stored book; when nothing changes it is returned as stored, at the same
+// version.
+func (u *BookUsecase) PatchBook(ctx context.Context, book *models.Book, fields []models.BookField) error {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Update)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.PatchBook")
+	defer span.End()
+
+	fields = slices.Clone(fields)
+	slices.Sort(fields)
+	fields = slices.Compact(fields)
+	for _, f := range fields {
+		if !f.Valid() {
+			return u.fail(ctx, "patch_book", errs.Validation("cannot update field %q", f))
+		}
+	}
+	if book.Version < 0 {
+		return u.fail(ctx, "patch_book", errs.Validation("version must not be negative"))
+	}
+	if slices.Contains(fields, models.FieldAuthor) && !slices.Contains(fields, models.FieldContributors) {
+		fields = append(fields, models.FieldContributors)
+		book.Contributors = nil
+	}
+
+	current, err := u.BookRepo.FindByID(ctx, book.ID)
+	if err != nil {
+		return u.fail(ctx, "patch_book", err)
+	}
+	if book.Version != 0 && book.Version != current.Version {
+		return u.fail(ctx, "patch_book", repository.VersionMismatch(book.ID, current.Version))
+	}
+
+	// Validate the book as it will be stored, then write back the
+	// normalised values of the patched fields.
+	merged := *current
+	merged.CopyFields(book, fields)
+	if err := validateBook(&merged); err != nil {
+		return u.fail(ctx, "patch_book", err)
 	}
-	u.logger.Println("Book updated successfully:", book)
+	book.CopyFields(&merged, fields)
+	if len(fields) == 0 {
+		*book = *current
+		return nil
+	}
+
+	if err := u.BookRepo.UpdateFields(ctx, book, fields); err != nil {
+		return u.fail(ctx, "patch_book", err)
+	}
+	u.metrics.BookUpdated()
+	u.logger.InfoContext(ctx, "book patched", "book_id", book.ID, "fields", fields)
 	return nil
 }
 
-func (u *BookUsecase) DeleteBook(id int) error {
-	u.logger.Println("Deleting book by ID:", id)
-	if err := u.BookRepo.DeleteBook(id); err != nil {
-		u.logger.Println("Error deleting book:", err)
+// DeleteBook moves a book to the trash if it is still at version, or
+// unconditionally when version is zero.
+func (u *BookUsecase) DeleteBook(ctx context.Context, id, version int) error {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Delete)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.DeleteBook")
+	defer span.End()
+
+	if version < 0 {
+		return u.fail(ctx, "delete_book", errs.Validation("version must not be negative"))
+	}
+	if err := u.BookRepo.Delete(ctx, id, version); err != nil {
+		return u.fail(ctx, "delete_book", err)
+	}
+	u.metrics.BookDeleted()
+	u.logger.InfoContext(ctx, "book deleted", "book_id", id)
+	return nil
+}
+
+// fail logs and counts a failed operation and returns err unchanged. Errors
+// caused by the caller are logged at info; the rest at error and also mark
+// the operation's span as failed.
+func (u *BookUsecase) fail(ctx context.Context, operation string, err error) error {
+	level := slog.LevelError
+	switch errs.KindOf(err) {
+	case errs.KindNotFound, errs.KindConflict, errs.KindValidation, errs.KindPrecondition:
+		level = slog.LevelInfo
+	}
+	if level == slog.LevelError {
+		span := trace.SpanFromContext(ctx)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	u.logger.Log(ctx, level, "operation failed", "operation", operation, "error", err)
+	u.metrics.OperationFailed(operation, err)
+	return err
+}
+
+// validateBook checks the fields required for a book to be stored and
+// normalises the ISBN and language to their canonical forms.
+func validateBook(book *models.Book) error {
+	switch {
+	case strings.TrimSpace(book.Title) == "":
+		return errs.Validation("title is required")
+	case len(book.Contributors) == 0 && strings.TrimSpace(book.Author) == "":
+		return errs.Validation("author is required")
+	case book.BookYear <= 0:
+		return errs.Validation("year must be a positive number")
+	case book.Pages < 0:
+		return errs.Validation("pages must not be negative")
+	case utf8.RuneCountInString(book.Description) > MaxDescriptionLength:
+		return errs.Validation("description must be at most %d characters", MaxDescriptionLength)
+	}
+	if err := validateContributors(book.Contributors); err != nil {
 		return err
 	}
-	u.logger.Println("Book deleted successfully, ID:", id)
+
+	if book.ISBN != "" {
+		isbn, err := models.NormalizeISBN(book.ISBN)
+		if err != nil {
+			return errs.Validation("invalid isbn %q: %v", book.ISBN, err)
+		}
+		book.ISBN = isbn
+	}
+	if book.Language != "" {
+		tag, err := language.Parse(book.Language)
+		if err != nil {
+			return errs.Validation("language %q is not a valid BCP 47 tag", book.Language)
+		}
+		book.Language = tag.String()
+	}
+	return nil
+}
+
+// validateContributors checks that every contributor names an author, by ID
+// or by name, in a supported role. An empty role means author.
+func validateContributors(contributors []models.Contributor) error {
+	for i := range contributors {
+		c := &contributors[i]
+		c.Name = strings.TrimSpace(c.Name)
+		if c.Role == "" {
+			c.Role = models.RoleAuthor
+		}
+		switch {
+		case c.AuthorID < 0:
+			return errs.Validation("author_id must be positive")
+		case c.AuthorID == 0 && models.AuthorKey(c.Name) == "":
+			return errs.Validation("contributor name is required")
+		case !c.Role.Valid():
+			return errs.Validation("role must be %s, %s or %s, got %q", models.RoleAuthor, models.RoleEditor, models.RoleTranslator, c.Role)
+		}
+	}
+	return nil
+}
+
+// normalizeListQuery validates query and fills in the default sort and limit.
+func normalizeListQuery(query *models.ListBooksQuery) error {
+	if query.SortBy == "" {
+		query.SortBy = models.SortByID
+	}
+	if !query.SortBy.Valid() {
+		return errs.Validation("cannot sort by %q", query.SortBy)
+	}
+
+	switch {
+	case query.Limit < 0:
+		return errs.Validation("limit must not be negative")
+	case query.Limit == 0:
+		query.Limit = DefaultPageSize
+	case query.Limit > MaxPageSize:
+		query.Limit = MaxPageSize
+	}
+
+	f := query.Filter
+	if f.AuthorID < 0 {
+		return errs.Validation("author_id must be positive")
+	}
+	if f.YearFrom < 0 || f.YearTo < 0 {
+		return errs.Validation("year filters must not be negative")
+	}
+	if f.YearFrom != 0 && f.YearTo != 0 && f.YearFrom > f.YearTo {
+		return errs.Validation("year_from must not be after year_to")
+	}
+	if f.ISBN != "" {
+		isbn, err := models.NormalizeISBN(f.ISBN)
+		if err != nil {
+			return errs.Validation("invalid isbn %q: %v", f.ISBN, err)
+		}
+		query.Filter.ISBN = isbn
+	}
 	return nil
 }
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// GetBookHistory returns every change made to a book, oldest first. The
+// history of a purged book remains available.
+func (u *BookUsecase) GetBookHistory(ctx context.Context, id int) ([]*models.BookChange, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Get)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.GetBookHistory")
+	defer span.End()
+
+	changes, err := u.BookRepo.History(ctx, id)
+	if err != nil {
+		return nil, u.fail(ctx, "get_book_history", err)
+	}
+	u.logger.DebugContext(ctx, "book history retrieved", "book_id", id, "count", len(changes))
+	return changes, nil
+}
+
+// GetBookAsOf returns a book as it stood at the given time. It fails with
+// errs.ErrNotFound if the book had not been created yet, or was in the trash.
+func (u *BookUsecase) GetBookAsOf(ctx context.Context, id int, at time.Time) (*models.Book, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Get)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.GetBookAsOf")
+	defer span.End()
+
+	book, err := u.BookRepo.FindAsOf(ctx, id, at)
+	if err != nil {
+		return nil, u.fail(ctx, "get_book_as_of", err)
+	}
+	u.logger.DebugContext(ctx, "book retrieved as of", "book_id", id, "as_of", at)
+	return book, nil
+}
+
+// ListChanges returns up to limit changes to any book made after the change
+// numbered afterID, oldest first. Readers that resume after the last ID they
+// saw miss nothing.
+func (u *BookUsecase) ListChanges(ctx context.Context, afterID, limit int) ([]*models.BookEvent, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.List)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.ListChanges")
+	defer span.End()
+
+	switch {
+	case afterID < 0:
+		return nil, u.fail(ctx, "list_changes", errs.Validation("change ID must not be negative"))
+	case limit < 0:
+		return nil, u.fail(ctx, "list_changes", errs.Validation("limit must not be negative"))
+	case limit == 0:
+		limit = DefaultPageSize
+	case limit > MaxPageSize:
+		limit = MaxPageSize
+	}
+	events, err := u.BookRepo.Changes(ctx, afterID, limit)
+	if err != nil {
+		return nil, u.fail(ctx, "list_changes", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,28 @@
+package usecases
+
+// Metrics receives the business events of a BookUsecase; *metrics.Metrics
+// implements it.
+type Metrics interface {
+	BookCreated()
+	BookUpdated()
+	BookDeleted()
+	BookRestored()
+	BooksPurged(n int)
+	OperationFailed(operation string, err error)
+}
+
+// WithMetrics records business events into m.
+func WithMetrics(m Metrics) Option {
+	return func(u *BookUsecase) {
+		u.metrics = m
+	}
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) BookCreated()                  {}
+func (nopMetrics) BookUpdated()                  {}
+func (nopMetrics) BookDeleted()                  {}
+func (nopMetrics) BookRestored()                 {}
+func (nopMetrics) BooksPurged(int)               {}
+func (nopMetrics) OperationFailed(string, error) {}
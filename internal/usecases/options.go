@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures a BookUsecase.
+type Option func(*BookUsecase)
+
+// Timeouts bounds each usecase operation, including the storage calls it
+// makes. A zero duration leaves the caller's deadline, if any, untouched.
+type Timeouts struct {
+	Create time.Duration
+	Get    time.Duration
+	List   time.Duration
+	Search time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// WithTimeouts sets per-operation timeouts.
+func WithTimeouts(t Timeouts) Option {
+	return func(u *BookUsecase) {
+		u.timeouts = t
+	}
+}
+
+// WithLogger sets the logger used for operation outcomes. It defaults to
+// slog.Default().
+func WithLogger(l *slog.Logger) Option {
+	return func(u *BookUsecase) {
+		u.logger = l
+	}
+}
+
+// WithTracerProvider creates the usecase's spans from tp instead of the
+// global provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(u *BookUsecase) {
+		u.tracer = tp.Tracer(tracerName)
+	}
+}
+
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
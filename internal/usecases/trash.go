@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+)
+
+// ListDeletedBooks returns one page of the books in the trash matching query.
+func (u *BookUsecase) ListDeletedBooks(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.List)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.ListDeletedBooks")
+	defer span.End()
+
+	query.Filter.Deleted = true
+	if err := normalizeListQuery(&query); err != nil {
+		return nil, u.fail(ctx, "list_deleted_books", err)
+	}
+	page, err := u.BookRepo.List(ctx, query)
+	if err != nil {
+		return nil, u.fail(ctx, "list_deleted_books", err)
+	}
+	u.logger.DebugContext(ctx, "deleted books listed", "sort_by", query.SortBy, "limit", query.Limit, "count", len(page.Books))
+	return page, nil
+}
+
+// RestoreBook takes a book out of the trash. It fails with a conflict if a
+// live book has taken its ISBN since it was deleted.
+func (u *BookUsecase) RestoreBook(ctx context.Context, id int) (*models.Book, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Update)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.RestoreBook")
+	defer span.End()
+
+	deleted, err := u.BookRepo.FindDeleted(ctx, id)
+	if err != nil {
+		return nil, u.fail(ctx, "restore_book", err)
+	}
+	if deleted.ISBN != "" {
+		page, err := u.BookRepo.List(ctx, models.ListBooksQuery{
+			Filter: models.BookFilter{ISBN: deleted.ISBN},
+			SortBy: models.SortByID,
+			Limit:  1,
+		})
+		if err != nil {
+			return nil, u.fail(ctx, "restore_book", err)
+		}
+		if len(page.Books) > 0 {
+			return nil, u.fail(ctx, "restore_book",
+				errs.Conflict("cannot restore book %d: book %d has the same isbn", id, page.Books[0].ID))
+		}
+	}
+
+	// The repository checks the ISBN again, for books created meanwhile.
+	book, err := u.BookRepo.Restore(ctx, id)
+	if err != nil {
+		return nil, u.fail(ctx, "restore_book", err)
+	}
+	u.metrics.BookRestored()
+	u.logger.InfoContext(ctx, "book restored", "book_id", id)
+	return book, nil
+}
+
+// PurgeDeletedBooks deletes for good the books that were moved to the trash
+// before deletedBefore, and returns how many there were.
+func (u *BookUsecase) PurgeDeletedBooks(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, cancel := withTimeout(ctx, u.timeouts.Delete)
+	defer cancel()
+	ctx, span := u.tracer.Start(ctx, "BookUsecase.PurgeDeletedBooks")
+	defer span.End()
+
+	n, err := u.BookRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		return 0, u.fail(ctx, "purge_books", err)
+	}
+	u.metrics.BooksPurged(n)
+	if n > 0 {
+		u.logger.InfoContext(ctx, "deleted books purged", "count", n, "deleted_before", deletedBefore)
+	}
+	return n, nil
+}
+
+// RunPurge purges the books that have been in the trash for longer than
+// retention, once immediately and then every interval, until ctx is done.
+// Failures are logged and retried on the next tick. Purges are recorded in
+// the books' history as made by audit.System.
+func (u *BookUsecase) RunPurge(ctx context.Context, interval, retention time.Duration) {
+	ctx = audit.WithActor(ctx, audit.System)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		u.PurgeDeletedBooks(ctx, time.Now().Add(-retention))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
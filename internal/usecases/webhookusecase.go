@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/interfaces"
+)
+
+var _ interfaces.WebhookUsecase = (*WebhookUsecase)(nil)
+
+// WebhookUsecase manages webhook subscriptions. The deliveries themselves
+// are driven by the events the book changes write to the outbox; see
+// package webhook. It shares the logger, tracer, metrics and timeouts of
+// the BookUsecase.
+type WebhookUsecase struct {
+	WebhookRepo repository.WebhookRepository
+	books       *BookUsecase
+}
+
+func NewWebhookUsecase(webhookRepo repository.WebhookRepository, books *BookUsecase) *WebhookUsecase {
+	return &WebhookUsecase{WebhookRepo: webhookRepo, books: books}
+}
+
+func (u *WebhookUsecase) AddWebhook(ctx context.Context, webhook *models.Webhook) error {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Create)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "WebhookUsecase.AddWebhook")
+	defer span.End()
+
+	if err := validateWebhook(webhook); err != nil {
+		return b.fail(ctx, "add_webhook", err)
+	}
+	if err := u.WebhookRepo.Create(ctx, webhook); err != nil {
+		return b.fail(ctx, "add_webhook", err)
+	}
+	b.logger.InfoContext(ctx, "webhook added", "webhook_id", webhook.ID, "url", webhook.URL)
+	return nil
+}
+
+func (u *WebhookUsecase) ListWebhooks(ctx context.Context) ([]*models.Webhook, error) {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.List)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "WebhookUsecase.ListWebhooks")
+	defer span.End()
+
+	webhooks, err := u.WebhookRepo.List(ctx)
+	if err != nil {
+		return nil, b.fail(ctx, "list_webhooks", err)
+	}
+	return webhooks, nil
+}
+
+func (u *WebhookUsecase) GetWebhook(ctx context.Context, id int) (*models.Webhook, error) {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Get)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "WebhookUsecase.GetWebhook")
+	defer span.End()
+
+	webhook, err := u.WebhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, b.fail(ctx, "get_webhook", err)
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook together with its deliveries.
+func (u *WebhookUsecase) DeleteWebhook(ctx context.Context, id int) error {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Delete)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "WebhookUsecase.DeleteWebhook")
+	defer span.End()
+
+	if err := u.WebhookRepo.Delete(ctx, id); err != nil {
+		return b.fail(ctx, "delete_webhook", err)
+	}
+	b.logger.InfoContext(ctx, "webhook deleted", "webhook_id", id)
+	return nil
+}
+
+// EnableWebhook re-enables a webhook that was disabled after repeated
+// failures. Its pending deliveries resume; events from while it was
+// disabled are not delivered.
+func (u *WebhookUsecase) EnableWebhook(ctx context.Context, id int) (*models.Webhook, error) {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.Update)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "WebhookUsecase.EnableWebhook")
+	defer span.End()
+
+	webhook, err := u.WebhookRepo.Enable(ctx, id)
+	if err != nil {
+		return nil, b.fail(ctx, "enable_webhook", err)
+	}
+	b.logger.InfoContext(ctx, "webhook enabled", "webhook_id", id)
+	return webhook, nil
+}
+
+// ListDeliveries returns the latest deliveries to a webhook, newest first.
+func (u *WebhookUsecase) ListDeliveries(ctx context.Context, webhookID, limit int) ([]*models.WebhookDelivery, error) {
+	b := u.books
+	ctx, cancel := withTimeout(ctx, b.timeouts.List)
+	defer cancel()
+	ctx, span := b.tracer.Start(ctx, "WebhookUsecase.ListDeliveries")
+	defer span.End()
+
+	switch {
+	case limit < 0:
+		return nil, b.fail(ctx, "list_webhook_deliveries", errs.Validation("limit must not be negative"))
+	case limit == 0:
+		limit = DefaultPageSize
+	case limit > MaxPageSize:
+		limit = MaxPageSize
+	}
+	deliveries, err := u.WebhookRepo.ListDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return nil, b.fail(ctx, "list_webhook_deliveries", err)
+	}
+	return deliveries, nil
+}
+
+func validateWebhook(webhook *models.Webhook) error {
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errs.Validation("url must be an absolute http or https URL")
+	}
+	for _, t := range webhook.EventTypes {
+		if !t.Valid() {
+			return errs.Validation("unknown event type %q", t)
+		}
+	}
+	if webhook.Secret == "" {
+		return errs.Validation("secret is required")
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/poll"
+)
+
+// Metrics receives the outcome of every delivery attempt; *metrics.Metrics
+// implements it.
+type Metrics interface {
+	WebhookDelivered(eventType models.BookEventType)
+	WebhookFailed(eventType models.BookEventType)
+	WebhookDisabled()
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) WebhookDelivered(models.BookEventType) {}
+func (nopMetrics) WebhookFailed(models.BookEventType)    {}
+func (nopMetrics) WebhookDisabled()                      {}
+
+// Dispatcher queues book events for the webhooks that want them and
+// delivers them.
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+
+	batchSize    int
+	lease        time.Duration
+	backoff      poll.Backoff
+	maxAttempts  int
+	disableAfter int
+	logger       *slog.Logger
+	metrics      Metrics
+}
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithClient sets the client deliveries are sent with. It defaults to
+// NewClient with a ten second timeout and no private targets.
+func WithClient(c *http.Client) Option {
+	return func(d *Dispatcher) { d.client = c }
+}
+
+// WithBatchSize sets how many deliveries are claimed, and sent concurrently,
+// at a time. It defaults to 50.
+func WithBatchSize(n int) Option {
+	return func(d *Dispatcher) { d.batchSize = n }
+}
+
+// WithLease sets how long a claimed batch has to be delivered before its
+// deliveries are offered again. It defaults to a minute.
+func WithLease(l time.Duration) Option {
+	return func(d *Dispatcher) { d.lease = l }
+}
+
+// WithBackoff sets the delay before retrying a delivery after its first
+// failed attempt, doubling with every further failure up to limit. It
+// defaults to ten seconds, up to an hour.
+func WithBackoff(initial, limit time.Duration) Option {
+	return func(d *Dispatcher) { d.backoff = poll.Backoff{Initial: initial, Max: limit} }
+}
+
+// WithMaxAttempts sets how many attempts a delivery gets before it is
+// marked failed. It defaults to 10.
+func WithMaxAttempts(n int) Option {
+	return func(d *Dispatcher) { d.maxAttempts = n }
+}
+
+// WithDisableAfter sets how many failed attempts in a row, across its
+// deliveries, disable a webhook. It defaults to 20; zero never disables.
+func WithDisableAfter(n int) Option {
+	return func(d *Dispatcher) { d.disableAfter = n }
+}
+
+// WithLogger sets the logger used for failed attempts. It defaults to
+// slog.Default().
+func WithLogger(l *slog.Logger) Option {
+	return func(d *Dispatcher) { d.logger = l }
+}
+
+// WithMetrics records delivery attempts into m.
+func WithMetrics(m Metrics) Option {
+	return func(d *Dispatcher) { d.metrics = m }
+}
+
+// NewDispatcher returns a dispatcher for the webhooks in repo.
+func NewDispatcher(repo repository.WebhookRepository, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		repo:         repo,
+		client:       NewClient(10*time.Second, false),
+		batchSize:    50,
+		lease:        time.Minute,
+		backoff:      poll.Backoff{Initial: 10 * time.Second, Max: time.Hour},
+		maxAttempts:  10,
+		disableAfter: 20,
+		logger:       slog.Default(),
+		metrics:      nopMetrics{},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Publish queues a delivery of event to every webhook that wants it. It is
+// meant to be subscribed to the outbox's Bus, which calls it at least once
+// for every book change; repeated calls for an event queue nothing more.
+func (d *Dispatcher) Publish(ctx context.Context, event *models.BookEvent) error {
+	n, err := d.repo.Enqueue(ctx, event)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		d.logger.DebugContext(ctx, "webhook deliveries queued",
+			"event_id", event.ID, "event_type", event.Type, "count", n)
+	}
+	return nil
+}
+
+// DispatchOnce claims a batch of due deliveries and sends them concurrently,
+// returning how many it claimed. Deliveries that fail are scheduled for a
+// retry; the error is only about the repository itself.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.ClaimDeliveries(ctx, d.batchSize, d.lease)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+	webhooks, err := d.repo.List(ctx)
+	if err != nil {
+		return len(deliveries), err
+	}
+	byID := make(map[int]*models.Webhook, len(webhooks))
+	for _, w := range webhooks {
+		byID[w.ID] = w
+	}
+
+	// Sending stops when the lease runs out, since other dispatchers may
+	// claim the rest of the batch from then on.
+	sendCtx, cancel := context.WithTimeout(ctx, d.lease)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		disabled = make(map[int]bool)
+	)
+	for _, delivery := range deliveries {
+		w, ok := byID[delivery.WebhookID]
+		if !ok {
+			continue // deleted since the claim, and its deliveries with it
+		}
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			updated, err := d.deliver(ctx, sendCtx, w, delivery)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			if updated != nil && updated.DisabledAt != nil && !disabled[w.ID] {
+				disabled[w.ID] = true
+				d.metrics.WebhookDisabled()
+				d.logger.WarnContext(ctx, "webhook disabled after repeated failures",
+					"webhook_id", w.ID, "failure_count", updated.FailureCount)
+			}
+		}(delivery)
+	}
+	wg.Wait()
+	return len(deliveries), firstErr
+}
+
+// deliver makes one attempt at delivery and records its outcome, returning
+// the webhook as updated.
+func (d *Dispatcher) deliver(ctx, sendCtx context.Context, w *models.Webhook, delivery *models.WebhookDelivery) (*models.Webhook, error) {
+	status, err := d.send(sendCtx, w, delivery)
+	if err != nil && ctx.Err() != nil {
+		// Shutting down: the lease will offer the delivery again.
+		return nil, ctx.Err()
+	}
+
+	attempt := models.DeliveryAttempt{ResponseStatus: status}
+	attempts := delivery.Attempts + 1
+	if err == nil {
+		d.metrics.WebhookDelivered(delivery.EventType)
+	} else {
+		attempt.Error = err.Error()
+		if attempts < d.maxAttempts {
+			retryAt := time.Now().Add(d.backoff.Delay(attempts))
+			attempt.RetryAt = &retryAt
+		}
+		d.metrics.WebhookFailed(delivery.EventType)
+		d.logger.WarnContext(ctx, "webhook delivery failed",
+			"webhook_id", w.ID, "delivery_id", delivery.ID, "event_type", delivery.EventType,
+			"attempts", attempts, "retry_at", attempt.RetryAt, "error", err)
+	}
+	updated, err := d.repo.RecordAttempt(ctx, delivery.ID, attempt, d.disableAfter)
+	if errors.Is(err, errs.ErrNotFound) {
+		return nil, nil // the webhook was deleted meanwhile
+	}
+	return updated, err
+}
+
+// send posts the delivery's payload to the webhook, returning the response
+// status if there was a response. Anything but a 2xx status is an error.
+func (d *Dispatcher) send(ctx context.Context, w *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.EventType))
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID))
+	req.Header.Set(SignatureHeader, Sign(w.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Run dispatches deliveries every interval, and straight away while full
+// batches keep coming, until ctx is done. Failures are logged and retried
+// on the next tick.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	poll.Loop{
+		Interval:  interval,
+		BatchSize: d.batchSize,
+		Once:      d.DispatchOnce,
+		OnError: func(ctx context.Context, err error) {
+			d.logger.ErrorContext(ctx, "dispatching webhook deliveries failed", "error", err)
+		},
+	}.Run(ctx)
+}
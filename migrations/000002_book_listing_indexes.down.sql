@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS books_author_lower_idx;
+DROP INDEX IF EXISTS books_year_id_idx;
+DROP INDEX IF EXISTS books_author_id_idx;
+DROP INDEX IF EXISTS books_title_id_idx;
@@ -0,0 +1,6 @@
+-- Keyset pagination orders by (<sort key>, id); text keys are compared with the
+-- "C" collation so that ordering doesn't depend on the database locale.
+CREATE INDEX IF NOT EXISTS books_title_id_idx ON books (title COLLATE "C", id);
+CREATE INDEX IF NOT EXISTS books_author_id_idx ON books (author COLLATE "C", id);
+CREATE INDEX IF NOT EXISTS books_year_id_idx ON books (year, id);
+CREATE INDEX IF NOT EXISTS books_author_lower_idx ON books (lower(author));
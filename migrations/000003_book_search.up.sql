@@ -0,0 +1,23 @@
+-- Installing pg_trgm takes a superuser or, since PostgreSQL 13, the database
+-- owner. Under a least-privileged role an administrator must run
+-- CREATE EXTENSION pg_trgm in the database beforehand; this is then a no-op.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE EXCEPTION 'extension pg_trgm is not installed and this role may not install it'
+        USING HINT = 'Have an administrator run CREATE EXTENSION pg_trgm in this database, then migrate again.';
+END
+$$;
+
+-- Titles weigh more than authors when ranking. The 'simple' configuration
+-- doesn't stem, which suits names and keeps parity with the in-memory index.
+ALTER TABLE books ADD COLUMN IF NOT EXISTS search tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(author, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS books_search_idx ON books USING GIN (search);
+CREATE INDEX IF NOT EXISTS books_title_trgm_idx ON books USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS books_author_trgm_idx ON books USING GIN (author gin_trgm_ops);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS books_isbn_key;
+ALTER TABLE books
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS edition,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS pages,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS publisher,
+    DROP COLUMN IF EXISTS isbn;
@@ -0,0 +1,13 @@
+-- ISBNs are stored as 13 digits; NULL when unknown, so that the unique index
+-- only applies to books that have one.
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS isbn TEXT CHECK (isbn ~ '^97[89][0-9]{10}$'),
+    ADD COLUMN IF NOT EXISTS publisher TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS pages INTEGER NOT NULL DEFAULT 0 CHECK (pages >= 0),
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS edition TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE UNIQUE INDEX IF NOT EXISTS books_isbn_key ON books (isbn);
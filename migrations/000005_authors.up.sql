@@ -0,0 +1,39 @@
+-- name_key is the author's identity: the letters and digits of the name,
+-- lowercased, as computed by models.AuthorKey.
+CREATE TABLE IF NOT EXISTS authors (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    name_key TEXT NOT NULL CHECK (name_key <> ''),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS authors_name_key_key ON authors (name_key);
+
+-- books.author is kept as the display line of the book's authors.
+CREATE TABLE IF NOT EXISTS book_authors (
+    book_id INTEGER NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL CONSTRAINT book_authors_author_id_fkey REFERENCES authors (id),
+    role TEXT NOT NULL CHECK (role IN ('author', 'editor', 'translator')),
+    position INTEGER NOT NULL CHECK (position >= 0),
+    PRIMARY KEY (book_id, author_id, role),
+    UNIQUE (book_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS book_authors_author_id_idx ON book_authors (author_id, book_id);
+
+-- Every existing author line becomes one author, spelled as on its oldest book.
+INSERT INTO authors (name, name_key)
+SELECT DISTINCT ON (name_key) btrim(author), name_key
+FROM (
+    SELECT id, author, lower(regexp_replace(author, '[^[:alnum:]]+', '', 'g')) AS name_key FROM books
+) b
+WHERE name_key <> ''
+ORDER BY name_key, id
+ON CONFLICT (name_key) DO NOTHING;
+
+INSERT INTO book_authors (book_id, author_id, role, position)
+SELECT b.id, a.id, 'author', 0
+FROM books b
+JOIN authors a ON a.name_key = lower(regexp_replace(b.author, '[^[:alnum:]]+', '', 'g'))
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+-- version is the optimistic concurrency token behind ETags: every update
+-- increments it and is only applied at the version the client last saw.
+ALTER TABLE books ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1 CHECK (version > 0);
@@ -0,0 +1,8 @@
+-- Books still in the trash are deleted for good.
+DELETE FROM books WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS books_deleted_at_idx;
+DROP INDEX IF EXISTS books_isbn_key;
+CREATE UNIQUE INDEX IF NOT EXISTS books_isbn_key ON books (isbn);
+
+ALTER TABLE books DROP COLUMN IF EXISTS deleted_at;
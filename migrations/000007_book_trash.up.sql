@@ -0,0 +1,10 @@
+-- Deleted books stay in the trash, with their links to authors, until they
+-- are restored or purged. Their ISBNs no longer count as taken, so the
+-- unique index only covers live books.
+ALTER TABLE books ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS books_isbn_key;
+CREATE UNIQUE INDEX IF NOT EXISTS books_isbn_key ON books (isbn) WHERE deleted_at IS NULL;
+
+-- Serves the trash listing and the purge.
+CREATE INDEX IF NOT EXISTS books_deleted_at_idx ON books (deleted_at) WHERE deleted_at IS NOT NULL;
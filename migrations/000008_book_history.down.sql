@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS book_history;
+DROP FUNCTION IF EXISTS book_history_append_only();
@@ -0,0 +1,57 @@
+-- book_history is the audit trail of books: one row per change, written in
+-- the transaction that makes it. Rows outlive purged books, so book_id has
+-- no foreign key.
+CREATE TABLE IF NOT EXISTS book_history (
+    id BIGSERIAL PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    operation TEXT NOT NULL CHECK (operation IN ('create', 'update', 'delete', 'restore', 'purge')),
+    actor TEXT NOT NULL,
+    -- The time the row was written, after the book's row lock was taken,
+    -- so that a book's changes are in changed_at order.
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp(),
+    -- [{"field": ..., "before": ..., "after": ...}] for creates and updates.
+    changes JSONB NOT NULL DEFAULT '[]',
+    -- The book as it stood after the change, as served by the API; NULL for
+    -- purges.
+    book JSONB
+);
+
+CREATE INDEX IF NOT EXISTS book_history_book_id_idx ON book_history (book_id, id);
+
+-- History is append-only.
+CREATE OR REPLACE FUNCTION book_history_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'book_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS book_history_append_only ON book_history;
+CREATE TRIGGER book_history_append_only BEFORE UPDATE OR DELETE ON book_history
+    FOR EACH ROW EXECUTE FUNCTION book_history_append_only();
+
+-- Existing books start their history with a snapshot of their current
+-- state. What happened to them before is unknown, so it is recorded as an
+-- update by "migration" at their last update.
+INSERT INTO book_history (book_id, version, operation, actor, changed_at, book)
+SELECT b.id, b.version, 'update', 'migration', b.updated_at, jsonb_build_object(
+        'id', b.id,
+        'title', b.title,
+        'author', b.author,
+        'contributors', COALESCE((
+            SELECT jsonb_agg(jsonb_build_object('author_id', a.id, 'name', a.name, 'role', ba.role) ORDER BY ba.position)
+            FROM book_authors ba JOIN authors a ON a.id = ba.author_id
+            WHERE ba.book_id = b.id), '[]'),
+        'year', b.year,
+        'isbn', COALESCE(b.isbn, ''),
+        'publisher', b.publisher,
+        'language', b.language,
+        'pages', b.pages,
+        'description', b.description,
+        'edition', b.edition,
+        'created_at', b.created_at,
+        'updated_at', b.updated_at,
+        'version', b.version,
+        'deleted_at', b.deleted_at)
+FROM books b
+WHERE NOT EXISTS (SELECT 1 FROM book_history h WHERE h.book_id = b.id);
@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL schema migrations so that the service
+// binary can apply them without access to the source tree.
+package migrations
+
+import "embed"
+
+// FS holds every NNNNNN_name.up.sql / NNNNNN_name.down.sql pair in this directory.
+//
+//go:embed *.sql
+var FS embed.FS
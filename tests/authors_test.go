@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// doJSON sends body, if any, as JSON along with the given header pairs.
+func doJSON(t *testing.T, method, url string, body any, header ...string) *http.Response {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req, err := http.NewRequest(method, url, &buf)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestIntegration_AuthorLifecycle(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	resp := doJSON(t, http.MethodPost, server.URL+"/authors", &models.Author{Name: "Octavia E. Butler"})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var author models.Author
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&author))
+	assert.NotZero(t, author.ID)
+	authorURL := server.URL + "/authors/" + strconv.Itoa(author.ID)
+
+	resp = doJSON(t, http.MethodPost, server.URL+"/authors", &models.Author{Name: "octavia e butler"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = doJSON(t, http.MethodPost, server.URL+"/books", &models.Book{
+		Title:    "Kindred",
+		BookYear: 1979,
+		Contributors: []models.Contributor{
+			{AuthorID: author.ID},
+			{Name: "Robert Crossley", Role: models.RoleEditor},
+		},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var book models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	assert.Equal(t, "Octavia E. Butler", book.Author)
+	assert.Len(t, book.Contributors, 2)
+
+	resp = doJSON(t, http.MethodGet, authorURL+"/books", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var books []models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&books))
+	require.Len(t, books, 1)
+	assert.Equal(t, book.ID, books[0].ID)
+
+	resp = doJSON(t, http.MethodPut, authorURL, &models.Author{Name: "Octavia Estelle Butler"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, http.MethodGet, server.URL+"/books/"+strconv.Itoa(book.ID), nil)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	assert.Equal(t, "Octavia Estelle Butler", book.Author)
+
+	resp = doJSON(t, http.MethodDelete, authorURL, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "linked authors can't be deleted")
+	doJSON(t, http.MethodDelete, server.URL+"/books/"+strconv.Itoa(book.ID), nil, "If-Match", "*")
+	resp = doJSON(t, http.MethodDelete, authorURL, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "books in the trash still link the author")
+	_, err := testRepository().Purge(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	resp = doJSON(t, http.MethodDelete, authorURL, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doJSON(t, http.MethodGet, authorURL+"/books", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestIntegration_ListAuthors(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	for _, name := range []string{"Listing Author C", "Listing Author A", "Listing Author B"} {
+		resp := doJSON(t, http.MethodPost, server.URL+"/authors", &models.Author{Name: name})
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	resp := doJSON(t, http.MethodGet, server.URL+"/authors?name_contains=listing+author&limit=2", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var authors []models.Author
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&authors))
+	require.Len(t, authors, 2)
+	assert.Equal(t, "Listing Author A", authors[0].Name)
+	token := resp.Header.Get(handlers.NextPageTokenHeader)
+	require.NotEmpty(t, token)
+
+	resp = doJSON(t, http.MethodGet, server.URL+"/authors?name_contains=listing+author&limit=2&page_token="+token, nil)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&authors))
+	require.Len(t, authors, 1)
+	assert.Equal(t, "Listing Author C", authors[0].Name)
+	assert.Empty(t, resp.Header.Get(handlers.NextPageTokenHeader))
+
+	resp = doJSON(t, http.MethodGet, server.URL+"/authors?limit=abc", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGRPC_AuthorService(t *testing.T) {
+	conn := setupGRPCConn(t)
+	bookClient, client := pb.NewBookServiceClient(conn), pb.NewAuthorServiceClient(conn)
+	ctx := context.Background()
+
+	author, err := client.CreateAuthor(ctx, &pb.Author{Name: "gRPC Author"})
+	require.NoError(t, err)
+	assert.NotNil(t, author.GetCreatedAt())
+
+	_, err = client.CreateAuthor(ctx, &pb.Author{Name: "GRPC author"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	book, err := bookClient.CreateBook(ctx, &pb.Book{
+		Title:        "gRPC Authored Book",
+		Year:         2024,
+		Contributors: []*pb.Contributor{{AuthorId: author.GetId()}, {Name: "gRPC Translator", Role: "translator"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "gRPC Author", book.GetAuthor())
+	require.Len(t, book.GetContributors(), 2)
+	assert.Equal(t, "translator", book.GetContributors()[1].GetRole())
+
+	books, err := client.ListAuthorBooks(ctx, &pb.ListAuthorBooksRequest{AuthorId: author.GetId()})
+	require.NoError(t, err)
+	require.Len(t, books.GetBooks(), 1)
+	assert.Equal(t, book.GetId(), books.GetBooks()[0].GetId())
+
+	_, err = client.GetAuthor(ctx, &pb.AuthorId{Id: 999999})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.DeleteAuthor(ctx, &pb.AuthorId{Id: author.GetId()})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
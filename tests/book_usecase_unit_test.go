@@ -0,0 +1,258 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubBookRepository records calls and returns canned results.
+type stubBookRepository struct {
+	created   []*models.Book
+	lastQuery models.ListBooksQuery
+	patched   []models.BookField
+	trashed   *models.Book // returned by FindDeleted
+	restored  []int
+	err       error
+	block     bool // FindByID waits for ctx to be done
+}
+
+func (s *stubBookRepository) Create(ctx context.Context, book *models.Book) error {
+	if s.err != nil {
+		return s.err
+	}
+	book.ID = len(s.created) + 1
+	s.created = append(s.created, book)
+	return nil
+}
+
+func (s *stubBookRepository) List(ctx context.Context, query models.ListBooksQuery) (*models.BookPage, error) {
+	s.lastQuery = query
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &models.BookPage{Books: s.created}, nil
+}
+
+func (s *stubBookRepository) Search(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error) {
+	return nil, s.err
+}
+
+func (s *stubBookRepository) FindByID(ctx context.Context, id int) (*models.Book, error) {
+	if s.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	if id < 1 || id > len(s.created) {
+		return nil, errs.NotFound("book %d not found", id)
+	}
+	book := *s.created[id-1]
+	return &book, nil
+}
+
+func (s *stubBookRepository) Update(ctx context.Context, book *models.Book) error { return s.err }
+
+func (s *stubBookRepository) UpdateFields(ctx context.Context, book *models.Book, fields []models.BookField) error {
+	s.patched = fields
+	return s.err
+}
+
+func (s *stubBookRepository) Delete(ctx context.Context, id, version int) error { return s.err }
+
+func (s *stubBookRepository) FindDeleted(ctx context.Context, id int) (*models.Book, error) {
+	if s.trashed == nil || s.trashed.ID != id {
+		return nil, repository.NotInTrash(id)
+	}
+	book := *s.trashed
+	return &book, nil
+}
+
+func (s *stubBookRepository) Restore(ctx context.Context, id int) (*models.Book, error) {
+	s.restored = append(s.restored, id)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.FindDeleted(ctx, id)
+}
+
+func (s *stubBookRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return 0, s.err
+}
+
+func (s *stubBookRepository) History(ctx context.Context, id int) ([]*models.BookChange, error) {
+	return nil, s.err
+}
+
+func (s *stubBookRepository) FindAsOf(ctx context.Context, id int, at time.Time) (*models.Book, error) {
+	return nil, s.err
+}
+
+func (s *stubBookRepository) Changes(ctx context.Context, afterID, limit int) ([]*models.BookEvent, error) {
+	return nil, s.err
+}
+
+func TestBookUsecase_AddBookUsesRepository(t *testing.T) {
+	repo := &stubBookRepository{}
+	uc := usecases.NewBookUsecase(repo)
+
+	book := &models.Book{Title: "Stubbed", Author: "Author Name", BookYear: 2020}
+	assert.NoError(t, uc.AddBook(context.Background(), book))
+	assert.Equal(t, 1, book.ID)
+	assert.Len(t, repo.created, 1)
+}
+
+func TestBookUsecase_ValidationSkipsRepository(t *testing.T) {
+	repo := &stubBookRepository{}
+	uc := usecases.NewBookUsecase(repo)
+
+	err := uc.AddBook(context.Background(), &models.Book{Title: "No Author", BookYear: 2020})
+	assert.True(t, errors.Is(err, errs.ErrValidation))
+	assert.Empty(t, repo.created)
+}
+
+func TestBookUsecase_NormalizesBookDetails(t *testing.T) {
+	uc := usecases.NewBookUsecase(&stubBookRepository{})
+
+	book := &models.Book{Title: "Hobbit", Author: "Tolkien", BookYear: 1937, ISBN: "0-261-10221-4", Language: "EN-gb"}
+	assert.NoError(t, uc.AddBook(context.Background(), book))
+	assert.Equal(t, "9780261102217", book.ISBN)
+	assert.Equal(t, "en-GB", book.Language)
+
+	for _, bad := range []*models.Book{
+		{Title: "Hobbit", Author: "Tolkien", BookYear: 1937, ISBN: "0-261-10221-5"},
+		{Title: "Hobbit", Author: "Tolkien", BookYear: 1937, Language: "not a language"},
+		{Title: "Hobbit", Author: "Tolkien", BookYear: 1937, Pages: -1},
+	} {
+		err := uc.AddBook(context.Background(), bad)
+		assert.True(t, errors.Is(err, errs.ErrValidation), "%+v: %v", bad, err)
+	}
+}
+
+func TestBookUsecase_ValidatesContributors(t *testing.T) {
+	repo := &stubBookRepository{}
+	uc := usecases.NewBookUsecase(repo)
+
+	book := &models.Book{Title: "Dune", BookYear: 1965, Contributors: []models.Contributor{{Name: " Frank Herbert "}}}
+	assert.NoError(t, uc.AddBook(context.Background(), book))
+	assert.Equal(t, models.Contributor{Name: "Frank Herbert", Role: models.RoleAuthor}, book.Contributors[0])
+
+	for _, bad := range [][]models.Contributor{
+		{{Name: "Frank Herbert", Role: "illustrator"}},
+		{{Name: " ... "}},
+		{{AuthorID: -1}},
+	} {
+		err := uc.AddBook(context.Background(), &models.Book{Title: "Dune", BookYear: 1965, Contributors: bad})
+		assert.True(t, errors.Is(err, errs.ErrValidation), "%+v: %v", bad, err)
+	}
+	assert.Len(t, repo.created, 1)
+}
+
+func TestBookUsecase_PatchBookWritesOnlyGivenFields(t *testing.T) {
+	repo := &stubBookRepository{}
+	uc := usecases.NewBookUsecase(repo)
+	ctx := context.Background()
+	stored := &models.Book{Title: "Dune", Author: "Frank Herbert", BookYear: 1965, Version: 3}
+	assert.NoError(t, uc.AddBook(ctx, stored))
+
+	patch := &models.Book{ID: stored.ID, Version: 3, ISBN: "0-441-17271-7", Title: "ignored"}
+	assert.NoError(t, uc.PatchBook(ctx, patch, []models.BookField{models.FieldISBN, models.FieldISBN}))
+	assert.Equal(t, []models.BookField{models.FieldISBN}, repo.patched)
+	assert.Equal(t, "9780441172719", patch.ISBN, "patched values are normalised")
+
+	repo.patched = nil
+	patch = &models.Book{ID: stored.ID, Version: 3, Author: "Brian Herbert"}
+	assert.NoError(t, uc.PatchBook(ctx, patch, []models.BookField{models.FieldAuthor}))
+	assert.ElementsMatch(t, []models.BookField{models.FieldAuthor, models.FieldContributors}, repo.patched)
+
+	for name, tc := range map[string]struct {
+		patch  *models.Book
+		fields []models.BookField
+		want   error
+	}{
+		"read-only field": {&models.Book{ID: stored.ID, Version: 3}, []models.BookField{"version"}, errs.ErrValidation},
+		"invalid value":   {&models.Book{ID: stored.ID, Version: 3}, []models.BookField{models.FieldTitle}, errs.ErrValidation},
+		"stale version":   {&models.Book{ID: stored.ID, Version: 2, Title: "New"}, []models.BookField{models.FieldTitle}, errs.ErrPrecondition},
+		"missing book":    {&models.Book{ID: 99, Title: "New"}, []models.BookField{models.FieldTitle}, errs.ErrNotFound},
+	} {
+		repo.patched = nil
+		err := uc.PatchBook(ctx, tc.patch, tc.fields)
+		assert.True(t, errors.Is(err, tc.want), "%s: %v", name, err)
+		assert.Nil(t, repo.patched, name)
+	}
+}
+
+func TestBookUsecase_PropagatesRepositoryErrors(t *testing.T) {
+	repo := &stubBookRepository{err: errs.Unavailable(errors.New("connection refused"), "database unavailable")}
+	uc := usecases.NewBookUsecase(repo)
+
+	_, err := uc.GetBooks(context.Background())
+	assert.True(t, errors.Is(err, errs.ErrUnavailable))
+
+	_, err = usecases.NewBookUsecase(&stubBookRepository{}).GetBookByID(context.Background(), 42)
+	assert.True(t, errors.Is(err, errs.ErrNotFound))
+}
+
+func TestBookUsecase_ListBooksNormalizesQuery(t *testing.T) {
+	repo := &stubBookRepository{}
+	uc := usecases.NewBookUsecase(repo)
+
+	_, err := uc.ListBooks(context.Background(), models.ListBooksQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, models.SortByID, repo.lastQuery.SortBy)
+	assert.Equal(t, usecases.DefaultPageSize, repo.lastQuery.Limit)
+
+	_, err = uc.ListBooks(context.Background(), models.ListBooksQuery{Limit: 1_000_000})
+	assert.NoError(t, err)
+	assert.Equal(t, usecases.MaxPageSize, repo.lastQuery.Limit)
+
+	invalid := []models.ListBooksQuery{
+		{SortBy: "isbn"},
+		{Limit: -1},
+		{Filter: models.BookFilter{YearFrom: 2000, YearTo: 1990}},
+	}
+	for _, query := range invalid {
+		_, err := uc.ListBooks(context.Background(), query)
+		assert.True(t, errors.Is(err, errs.ErrValidation), "%+v", query)
+	}
+}
+
+func TestBookUsecase_AppliesOperationTimeout(t *testing.T) {
+	uc := usecases.NewBookUsecase(&stubBookRepository{block: true}, usecases.WithTimeouts(usecases.Timeouts{Get: 10 * time.Millisecond}))
+
+	start := time.Now()
+	_, err := uc.GetBookByID(context.Background(), 1)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestBookUsecase_RestoreBookRejectsTakenISBN(t *testing.T) {
+	repo := &stubBookRepository{trashed: &models.Book{ID: 7, Title: "Dune", Author: "Frank Herbert", BookYear: 1965, ISBN: "9780441172719"}}
+	uc := usecases.NewBookUsecase(repo)
+	ctx := context.Background()
+	assert.NoError(t, uc.AddBook(ctx, &models.Book{Title: "Dune (reprint)", Author: "Frank Herbert", BookYear: 1990, ISBN: "0-441-17271-7"}))
+
+	_, err := uc.RestoreBook(ctx, 7)
+	assert.True(t, errors.Is(err, errs.ErrConflict), "got %v", err)
+	assert.Equal(t, "9780441172719", repo.lastQuery.Filter.ISBN)
+	assert.False(t, repo.lastQuery.Filter.Deleted, "the ISBN is looked up among live books")
+	assert.Empty(t, repo.restored)
+
+	repo.created = nil
+	book, err := uc.RestoreBook(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "Dune", book.Title)
+	assert.Equal(t, []int{7}, repo.restored)
+
+	_, err = uc.RestoreBook(ctx, 8)
+	assert.True(t, errors.Is(err, errs.ErrNotFound))
+}
@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/memory"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	"github.com/Dias221467/MicroServices/internal/interfaces/router"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseEvent is one event read from a text/event-stream body.
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// changeStream is an open GET /books/changes response.
+type changeStream struct {
+	resp   *http.Response
+	reader *bufio.Reader
+}
+
+func newChangesServer(t *testing.T) *httptest.Server {
+	bookUsecase := usecases.NewBookUsecase(memory.NewBookRepository())
+	server := httptest.NewServer(router.New(handlers.NewBookHandler(bookUsecase,
+		handlers.WithChangeFeed(10*time.Millisecond, 50*time.Millisecond)),
+		router.WithMiddleware(audit.Middleware),
+	))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func openChanges(t *testing.T, url string, header ...string) *changeStream {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cancel()
+		resp.Body.Close()
+	})
+	return &changeStream{resp: resp, reader: bufio.NewReader(resp.Body)}
+}
+
+// next reads the next event, returning comments such as heartbeats as
+// events with only Data set.
+func (s *changeStream) next(t *testing.T) sseEvent {
+	t.Helper()
+	var event sseEvent
+	for {
+		line, err := s.reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return event
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data", "":
+			event.Data = value
+		}
+	}
+}
+
+func createBookAPI(t *testing.T, baseURL, title string) models.Book {
+	t.Helper()
+	resp := doJSON(t, http.MethodPost, baseURL+"/books",
+		map[string]any{"title": title, "author": "Stream Author", "year": 2001})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var book models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	return book
+}
+
+func TestChanges_StreamResumesAfterLastEventID(t *testing.T) {
+	server := newChangesServer(t)
+
+	book := createBookAPI(t, server.URL, "Streamed")
+	resp := doJSON(t, http.MethodPut, server.URL+"/books/"+strconv.Itoa(book.ID),
+		map[string]any{"title": "Streamed Again", "author": "Stream Author", "year": 2001},
+		"If-Match", handlers.ETag(book.Version))
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = doJSON(t, http.MethodDelete, server.URL+"/books/"+strconv.Itoa(book.ID), nil,
+		"If-Match", handlers.ETag(book.Version+1))
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	stream := openChanges(t, server.URL+"/books/changes")
+	require.Equal(t, http.StatusOK, stream.resp.StatusCode)
+	assert.Equal(t, "text/event-stream", stream.resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", stream.resp.Header.Get("Cache-Control"))
+
+	var (
+		seen []sseEvent
+		ids  []int
+	)
+	for _, want := range []models.BookEventType{models.BookCreated, models.BookUpdated, models.BookDeleted} {
+		event := stream.next(t)
+		assert.Equal(t, string(want), event.Event)
+		var decoded models.BookEvent
+		require.NoError(t, json.Unmarshal([]byte(event.Data), &decoded))
+		assert.Equal(t, book.ID, decoded.BookID)
+		assert.Equal(t, event.ID, strconv.Itoa(decoded.ID))
+		seen, ids = append(seen, event), append(ids, decoded.ID)
+	}
+	assert.True(t, ids[0] < ids[1] && ids[1] < ids[2], "ids %v out of order", ids)
+
+	// Changes made while the stream is open arrive live.
+	live := createBookAPI(t, server.URL, "Live")
+	event := stream.next(t)
+	assert.Equal(t, string(models.BookCreated), event.Event)
+	assert.Contains(t, event.Data, `"title":"Live"`)
+
+	// A client reconnecting after the update gets only what followed it.
+	missed := createBookAPI(t, server.URL, "Missed")
+	resumed := openChanges(t, server.URL+"/books/changes", handlers.LastEventIDHeader, seen[1].ID)
+	require.Equal(t, http.StatusOK, resumed.resp.StatusCode)
+	assert.Equal(t, seen[2].ID, resumed.next(t).ID)
+	assert.Contains(t, resumed.next(t).Data, `"book_id":`+strconv.Itoa(live.ID))
+	assert.Contains(t, resumed.next(t).Data, `"book_id":`+strconv.Itoa(missed.ID))
+
+	// The query parameter serves clients that can't set headers.
+	byQuery := openChanges(t, server.URL+"/books/changes?last_event_id="+event.ID)
+	assert.Contains(t, byQuery.next(t).Data, `"title":"Missed"`)
+
+	// An idle stream is kept open with heartbeat comments.
+	assert.Equal(t, sseEvent{Data: "heartbeat"}, byQuery.next(t))
+}
+
+func TestChanges_InvalidLastEventID(t *testing.T) {
+	server := newChangesServer(t)
+
+	for _, header := range []string{"abc", "-1"} {
+		stream := openChanges(t, server.URL+"/books/changes", handlers.LastEventIDHeader, header)
+		assert.Equal(t, http.StatusBadRequest, stream.resp.StatusCode, header)
+	}
+	stream := openChanges(t, server.URL+"/books/changes?last_event_id=")
+	assert.Equal(t, http.StatusBadRequest, stream.resp.StatusCode)
+}
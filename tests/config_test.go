@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestConfig_LoadFileWithEnvOverrides(t *testing.T) {
+	path := writeConfig(t, `
+database:
+  dsn: postgresql://file@localhost/books
+  max_open_conns: 20
+http:
+  addr: ":9090"
+  read_timeout: 3s
+`)
+	t.Setenv("DATABASE_DSN", "postgresql://env@localhost/books")
+	t.Setenv("GRPC_ADDR", ":6000")
+
+	cfg, err := config.Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "postgresql://env@localhost/books", cfg.Database.DSN)
+	assert.Equal(t, 20, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 5, cfg.Database.MaxIdleConns, "unset values keep their defaults")
+	assert.Equal(t, ":9090", cfg.HTTP.Addr)
+	assert.Equal(t, 3*time.Second, cfg.HTTP.ReadTimeout)
+	assert.Equal(t, ":6000", cfg.GRPC.Addr)
+}
+
+func TestConfig_MissingDSN(t *testing.T) {
+	t.Setenv("DATABASE_DSN", "")
+
+	_, err := config.Load(writeConfig(t, "http:\n  addr: \":8080\"\n"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "database.dsn is required")
+	}
+}
+
+func TestConfig_InvalidEnvValue(t *testing.T) {
+	t.Setenv("DATABASE_DSN", "postgresql://env@localhost/books")
+	t.Setenv("HTTP_READ_TIMEOUT", "soon")
+
+	_, err := config.Load("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "HTTP_READ_TIMEOUT")
+	}
+}
+
+func TestConfig_ShutdownSettings(t *testing.T) {
+	t.Setenv("DATABASE_DSN", "postgresql://env@localhost/books")
+	t.Setenv("SHUTDOWN_DRAIN_DELAY", "5s")
+
+	cfg, err := config.Load(writeConfig(t, "shutdown:\n  timeout: 30s\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.Shutdown.DrainDelay)
+	assert.Equal(t, 30*time.Second, cfg.Shutdown.Timeout)
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "0s")
+	_, err = config.Load("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "shutdown.timeout must be positive")
+	}
+}
+
+func TestConfig_TrashSettings(t *testing.T) {
+	t.Setenv("DATABASE_DSN", "postgresql://env@localhost/books")
+	t.Setenv("TRASH_RETENTION", "168h")
+
+	cfg, err := config.Load(writeConfig(t, "trash:\n  purge_interval: 10m\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, cfg.Trash.Retention)
+	assert.Equal(t, 10*time.Minute, cfg.Trash.PurgeInterval)
+
+	t.Setenv("TRASH_PURGE_INTERVAL", "0s")
+	_, err = config.Load("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "trash.purge_interval must be positive")
+	}
+
+	// Without a retention nothing is purged, so no interval is needed.
+	t.Setenv("TRASH_RETENTION", "0s")
+	_, err = config.Load("")
+	assert.NoError(t, err)
+}
+
+func TestConfig_OutboxSettings(t *testing.T) {
+	t.Setenv("DATABASE_DSN", "postgresql://env@localhost/books")
+
+	cfg, err := config.Load("")
+	assert.NoError(t, err)
+	assert.Equal(t, config.OutboxSinkNone, cfg.Outbox.Sink)
+
+	t.Setenv("OUTBOX_SINK", config.OutboxSinkKafkaREST)
+	t.Setenv("OUTBOX_BATCH_SIZE", "25")
+	cfg, err = config.Load(writeConfig(t, "outbox:\n  broker_url: http://localhost:8082\n  retry_backoff: 2s\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8082", cfg.Outbox.BrokerURL)
+	assert.Equal(t, "books.events", cfg.Outbox.Topic)
+	assert.Equal(t, 25, cfg.Outbox.BatchSize)
+	assert.Equal(t, 2*time.Second, cfg.Outbox.RetryBackoff)
+
+	_, err = config.Load("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "outbox.broker_url and outbox.topic are required")
+	}
+
+	t.Setenv("OUTBOX_SINK", "carrier-pigeon")
+	_, err = config.Load("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "outbox.sink must be none, file or kafka_rest")
+	}
+}
+
+func TestConfig_WebhookSettings(t *testing.T) {
+	t.Setenv("DATABASE_DSN", "postgresql://env@localhost/books")
+
+	cfg, err := config.Load("")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, cfg.Webhooks.MaxAttempts)
+	assert.Equal(t, 20, cfg.Webhooks.DisableAfter)
+	assert.False(t, cfg.Webhooks.AllowPrivateTargets)
+
+	t.Setenv("WEBHOOKS_DISABLE_AFTER", "5")
+	t.Setenv("WEBHOOKS_ALLOW_PRIVATE_TARGETS", "true")
+	cfg, err = config.Load(writeConfig(t, "webhooks:\n  timeout: 3s\n  max_retry_backoff: 10m\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, cfg.Webhooks.DisableAfter)
+	assert.True(t, cfg.Webhooks.AllowPrivateTargets)
+	assert.Equal(t, 3*time.Second, cfg.Webhooks.Timeout)
+	assert.Equal(t, 10*time.Minute, cfg.Webhooks.MaxRetryBackoff)
+
+	_, err = config.Load(writeConfig(t, "webhooks:\n  max_attempts: 0\n  retry_backoff: 2h\n"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "webhooks.max_attempts must be positive")
+		assert.Contains(t, err.Error(), "webhooks.retry_backoff must be positive and at most webhooks.max_retry_backoff")
+	}
+}
+
+func TestConfig_ChangesSettings(t *testing.T) {
+	t.Setenv("DATABASE_DSN", "postgresql://env@localhost/books")
+	t.Setenv("CHANGES_HEARTBEAT", "30s")
+
+	cfg, err := config.Load(writeConfig(t, "changes:\n  poll_interval: 250ms\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, cfg.Changes.PollInterval)
+	assert.Equal(t, 30*time.Second, cfg.Changes.Heartbeat)
+
+	_, err = config.Load(writeConfig(t, "changes:\n  poll_interval: 0s\n"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "changes.poll_interval and changes.heartbeat must be positive")
+	}
+}
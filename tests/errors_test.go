@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	"github.com/Dias221467/MicroServices/internal/interfaces/rpc"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestErrs_KindSurvivesWrapping(t *testing.T) {
+	err := fmt.Errorf("loading book: %w", errs.NotFound("book %d not found", 7))
+
+	assert.True(t, errors.Is(err, errs.ErrNotFound))
+	assert.False(t, errors.Is(err, errs.ErrConflict))
+	assert.Equal(t, errs.KindNotFound, errs.KindOf(err))
+	assert.Equal(t, errs.KindUnknown, errs.KindOf(errors.New("boom")))
+}
+
+func TestHTTPStatus(t *testing.T) {
+	cases := map[error]int{
+		errs.NotFound("missing"):                           http.StatusNotFound,
+		errs.Conflict("duplicate"):                         http.StatusConflict,
+		errs.Validation("bad input"):                       http.StatusBadRequest,
+		errs.Unavailable(errors.New("refused"), "db down"): http.StatusServiceUnavailable,
+		errs.PreconditionFailed("stale"):                   http.StatusPreconditionFailed,
+		errors.New("boom"):                                 http.StatusInternalServerError,
+		fmt.Errorf("query: %w", context.DeadlineExceeded):  http.StatusGatewayTimeout,
+	}
+	for err, want := range cases {
+		assert.Equal(t, want, handlers.HTTPStatus(err), err.Error())
+	}
+}
+
+// driverErrors are classified errors wrapping the kind of driver error the
+// postgres adapter translates, each with the detail that mustn't reach clients.
+var driverErrors = []struct {
+	err    error
+	status int
+	code   codes.Code
+	msg    string
+	detail string
+}{
+	{
+		err: &errs.Error{Kind: errs.KindConflict, Message: "a book with this isbn already exists", Err: &pq.Error{
+			Code: "23505", Constraint: "books_isbn_key",
+			Message: `duplicate key value violates unique constraint "books_isbn_key"`,
+		}},
+		status: http.StatusConflict,
+		code:   codes.AlreadyExists,
+		msg:    "a book with this isbn already exists",
+		detail: "books_isbn_key",
+	},
+	{
+		err: errs.Unavailable(&net.OpError{Op: "dial", Net: "tcp",
+			Addr: &net.TCPAddr{IP: net.IPv4(10, 1, 2, 3), Port: 5432}, Err: errors.New("connection refused")},
+			"database unavailable"),
+		status: http.StatusServiceUnavailable,
+		code:   codes.Unavailable,
+		msg:    "database unavailable",
+		detail: "10.1.2.3:5432",
+	},
+}
+
+func TestWriteError_HidesWrappedCause(t *testing.T) {
+	for _, tc := range driverErrors {
+		rec := httptest.NewRecorder()
+		handlers.WriteError(rec, fmt.Errorf("adding book: %w", tc.err))
+
+		assert.Equal(t, tc.status, rec.Code)
+		assert.Equal(t, tc.msg+"\n", rec.Body.String())
+		assert.NotContains(t, rec.Body.String(), tc.detail)
+	}
+}
+
+func TestGRPC_StatusHidesWrappedCause(t *testing.T) {
+	for _, tc := range driverErrors {
+		lis := bufconn.Listen(1024 * 1024)
+		srv := grpc.NewServer()
+		pb.RegisterBookServiceServer(srv, rpc.NewBookServiceServer(usecases.NewBookUsecase(&stubBookRepository{err: tc.err})))
+		go srv.Serve(lis)
+		conn, err := grpc.DialContext(context.Background(), "bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+
+		_, err = pb.NewBookServiceClient(conn).CreateBook(context.Background(),
+			&pb.Book{Title: "Leaky", Author: "Test Author", Year: 2024})
+		conn.Close()
+		srv.Stop()
+
+		st := status.Convert(err)
+		assert.Equal(t, tc.code, st.Code())
+		assert.Equal(t, tc.msg, st.Message())
+		assert.NotContains(t, st.Message(), tc.detail)
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIntegration_ETagsGuardConcurrentEdits(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	resp := doJSON(t, http.MethodPost, server.URL+"/books", &models.Book{Title: "Versioned", Author: "Test Author", BookYear: 2024})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var book models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	url := server.URL + "/books/" + strconv.Itoa(book.ID)
+
+	resp = doJSON(t, http.MethodGet, url, nil)
+	etag := resp.Header.Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	book.Title = "Edited"
+	resp = doJSON(t, http.MethodPut, url, &book)
+	assert.Equal(t, http.StatusPreconditionRequired, resp.StatusCode)
+	resp = doJSON(t, http.MethodDelete, url, nil)
+	assert.Equal(t, http.StatusPreconditionRequired, resp.StatusCode)
+
+	resp = doJSON(t, http.MethodPut, url, &book, "If-Match", etag)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	// A second editor still holding the first ETag loses the race.
+	book.Title = "Stale edit"
+	for _, ifMatch := range []string{etag, `W/"2"`, `"something-else"`} {
+		resp = doJSON(t, http.MethodPut, url, &book, "If-Match", ifMatch)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode, ifMatch)
+	}
+	resp = doJSON(t, http.MethodDelete, url, nil, "If-Match", etag)
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = doJSON(t, http.MethodGet, url, nil)
+	var got models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	assert.Equal(t, "Edited", got.Title)
+	assert.Equal(t, 2, got.Version)
+
+	resp = doJSON(t, http.MethodDelete, url, nil, "If-Match", `"2"`)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestGRPC_VersionsGuardConcurrentEdits(t *testing.T) {
+	client := setupGRPCClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &pb.Book{Title: "gRPC Versioned", Author: "Test Author", Year: 2024})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), created.GetVersion())
+
+	edit := &pb.Book{Id: created.GetId(), Title: "Edited", Author: "Test Author", Year: 2024}
+	_, err = client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: edit})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "version is required")
+
+	edit.Version = created.GetVersion()
+	updated, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: edit})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), updated.GetVersion())
+
+	_, err = client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: edit})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "stale version")
+	_, err = client.DeleteBook(ctx, &pb.BookId{Id: created.GetId()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = client.DeleteBook(ctx, &pb.BookId{Id: created.GetId(), Version: updated.GetVersion()})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/interfaces/rpc"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func setupGRPCClient(t *testing.T) pb.BookServiceClient {
+	return pb.NewBookServiceClient(setupGRPCConn(t))
+}
+
+// setupGRPCConn serves the book and author services over an in-memory
+// listener and returns a connection to them.
+func setupGRPCConn(t *testing.T) *grpc.ClientConn {
+	setup()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(audit.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(audit.StreamServerInterceptor()),
+	)
+	pb.RegisterBookServiceServer(srv, rpc.NewBookServiceServer(usecase))
+	pb.RegisterAuthorServiceServer(srv, rpc.NewAuthorServiceServer(usecases.NewAuthorUsecase(testAuthorRepository(), usecase)))
+	go srv.Serve(lis)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+		teardown()
+	})
+	return conn
+}
+
+func TestGRPC_BookLifecycle(t *testing.T) {
+	client := setupGRPCClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &pb.Book{Title: "gRPC Test Book", Author: "Test Author", Year: 2024})
+	assert.NoError(t, err)
+	assert.NotZero(t, created.GetId())
+
+	got, err := client.GetBook(ctx, &pb.BookId{Id: created.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, "gRPC Test Book", got.GetTitle())
+
+	got.Title = "Updated gRPC Title"
+	updated, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: got})
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated gRPC Title", updated.GetTitle())
+
+	list, err := client.GetBooks(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(list.GetBooks()), 1)
+
+	_, err = client.DeleteBook(ctx, &pb.BookId{Id: created.GetId(), Version: updated.GetVersion()})
+	assert.NoError(t, err)
+
+	_, err = client.GetBook(ctx, &pb.BookId{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/health"
+	"github.com/stretchr/testify/assert"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakeSchema struct {
+	current, latest uint
+}
+
+func (f fakeSchema) Current(context.Context) (uint, error) { return f.current, nil }
+func (f fakeSchema) Latest() uint                          { return f.latest }
+
+func TestHealth_Liveness(t *testing.T) {
+	rr := httptest.NewRecorder()
+	health.LivenessHandler(rr, httptest.NewRequest("GET", "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"up"}`, rr.Body.String())
+}
+
+func TestHealth_ReadinessReportsEachCheck(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("database", func(context.Context) error { return nil })
+	checker.Register("schema", health.SchemaVersion(fakeSchema{current: 2, latest: 3}))
+
+	rr := httptest.NewRecorder()
+	checker.ReadinessHandler(rr, httptest.NewRequest("GET", "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	var report health.Report
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+	assert.Equal(t, health.StatusDown, report.Checks["schema"].Status)
+	assert.Contains(t, report.Checks["schema"].Error, "version 2, expected 3")
+
+	checker.Register("schema", health.SchemaVersion(fakeSchema{current: 3, latest: 3}))
+	rr = httptest.NewRecorder()
+	checker.ReadinessHandler(rr, httptest.NewRequest("GET", "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestHealth_SchemaAheadOfBuildIsReady(t *testing.T) {
+	// Old replicas keep serving while a newer build migrates the schema.
+	check := health.SchemaVersion(fakeSchema{current: 4, latest: 3})
+	assert.NoError(t, check(context.Background()))
+}
+
+func TestHealth_CheckTimeout(t *testing.T) {
+	checker := health.NewChecker(10 * time.Millisecond)
+	checker.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Run(context.Background())
+	assert.False(t, report.Up())
+	assert.Contains(t, report.Checks["slow"].Error, "deadline exceeded")
+}
+
+func TestHealth_WatchPublishesGRPCStatus(t *testing.T) {
+	var healthy = make(chan bool, 1)
+	healthy <- false
+	checker := health.NewChecker(time.Second)
+	checker.Register("dependency", func(context.Context) error {
+		ok := <-healthy
+		healthy <- ok
+		if !ok {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	srv := grpchealth.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Watch(ctx, srv, 5*time.Millisecond, "book.BookService")
+
+	statusOf := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := srv.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+		return resp.Status
+	}
+
+	assert.Eventually(t, func() bool {
+		return statusOf("book.BookService") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	<-healthy
+	healthy <- true
+	assert.Eventually(t, func() bool {
+		return statusOf("") == healthpb.HealthCheckResponse_SERVING &&
+			statusOf("book.BookService") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestIntegration_BookHistory(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	resp := doJSON(t, http.MethodPost, server.URL+"/books", &models.Book{Title: "Palimpsest", Author: "Test Author", BookYear: 2024},
+		audit.ActorHeader, "alice")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var book models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	bookURL := server.URL + "/books/" + strconv.Itoa(book.ID)
+
+	book.Title = "Palimpsest, Revised"
+	resp = doJSON(t, http.MethodPut, bookURL, &book, "If-Match", handlers.ETag(book.Version), audit.ActorHeader, "bob")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doJSON(t, http.MethodGet, bookURL+"/history", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var changes []models.BookChange
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&changes))
+	require.Len(t, changes, 2)
+	assert.Equal(t, models.OpCreate, changes[0].Operation)
+	assert.Equal(t, "alice", changes[0].Actor)
+	assert.Equal(t, models.OpUpdate, changes[1].Operation)
+	assert.Equal(t, "bob", changes[1].Actor)
+	require.Len(t, changes[1].Changes, 1)
+	assert.JSONEq(t, `"Palimpsest"`, string(changes[1].Changes[0].Before))
+	assert.JSONEq(t, `"Palimpsest, Revised"`, string(changes[1].Changes[0].After))
+
+	asOf := func(at time.Time) *http.Response {
+		return doJSON(t, http.MethodGet, bookURL+"?as_of="+url.QueryEscape(at.Format(time.RFC3339Nano)), nil)
+	}
+	resp = asOf(changes[0].ChangedAt)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("ETag"), "past versions can't be matched")
+	var past models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&past))
+	assert.Equal(t, "Palimpsest", past.Title)
+	assert.Equal(t, http.StatusNotFound, asOf(changes[0].ChangedAt.Add(-time.Second)).StatusCode)
+
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, http.MethodGet, bookURL+"?as_of=yesterday", nil).StatusCode)
+	assert.Equal(t, http.StatusNotFound, doJSON(t, http.MethodGet, server.URL+"/books/999999/history", nil).StatusCode)
+}
+
+func TestGRPC_BookHistory(t *testing.T) {
+	client := setupGRPCClient(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), audit.ActorMetadata, "carol")
+
+	created, err := client.CreateBook(ctx, &pb.Book{Title: "gRPC History Book", Author: "Test Author", Year: 2024})
+	require.NoError(t, err)
+	_, err = client.DeleteBook(ctx, &pb.BookId{Id: created.GetId(), Version: created.GetVersion()})
+	require.NoError(t, err)
+
+	resp, err := client.GetBookHistory(ctx, &pb.GetBookHistoryRequest{Id: created.GetId()})
+	require.NoError(t, err)
+	require.Len(t, resp.GetChanges(), 2)
+	first := resp.GetChanges()[0]
+	assert.Equal(t, "create", first.GetOperation())
+	assert.Equal(t, "carol", first.GetActor())
+	require.NotEmpty(t, first.GetChanges())
+	assert.Equal(t, "title", first.GetChanges()[0].GetField())
+	assert.Nil(t, first.GetChanges()[0].GetBefore())
+	assert.Equal(t, "gRPC History Book", first.GetChanges()[0].GetAfter().GetStringValue())
+	assert.Equal(t, "delete", resp.GetChanges()[1].GetOperation())
+
+	got, err := client.GetBook(ctx, &pb.BookId{Id: created.GetId(), AsOf: first.GetChangedAt()})
+	require.NoError(t, err)
+	assert.Equal(t, "gRPC History Book", got.GetTitle())
+
+	_, err = client.GetBook(ctx, &pb.BookId{Id: created.GetId(), AsOf: timestamppb.Now()})
+	assert.Equal(t, codes.NotFound, status.Code(err), "the book is in the trash now")
+	_, err = client.GetBookHistory(ctx, &pb.GetBookHistoryRequest{Id: 999999})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
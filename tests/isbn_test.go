@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	valid := map[string]string{
+		"9780306406157":     "9780306406157",
+		"978-0-306-40615-7": "9780306406157",
+		"0306406152":        "9780306406157",
+		"0-8044-2957-X":     "9780804429573",
+		"080442957x":        "9780804429573",
+		"979-10-90636-07-1": "9791090636071",
+	}
+	for in, want := range valid {
+		got, err := models.NormalizeISBN(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{
+		"",
+		"9780306406158", // bad check digit
+		"0306406153",    // bad check digit
+		"1234567890123", // not a Bookland prefix
+		"030640615",     // too short
+		"97803064061X7", // letter inside an ISBN-13
+		"X306406152",    // X only allowed last
+	} {
+		_, err := models.NormalizeISBN(in)
+		assert.Error(t, err, in)
+	}
+}
@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/config"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/health"
+	"github.com/Dias221467/MicroServices/internal/interfaces/rpc"
+	"github.com/Dias221467/MicroServices/internal/lifecycle"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// gatedBookRepository holds FindByID open until gate is closed, or until its
+// context is done when gate is nil, then reports the book missing.
+type gatedBookRepository struct {
+	stubBookRepository
+	started chan struct{}
+	gate    <-chan struct{}
+	done    func()
+}
+
+func (g *gatedBookRepository) FindByID(ctx context.Context, id int) (*models.Book, error) {
+	close(g.started)
+	select {
+	case <-g.gate:
+	case <-ctx.Done():
+	}
+	if g.done != nil {
+		g.done()
+	}
+	return g.stubBookRepository.FindByID(ctx, id)
+}
+
+// shutdownEnv is a running HTTP and gRPC server pair for a Lifecycle to stop.
+type shutdownEnv struct {
+	httpServer *http.Server
+	httpURL    string
+	grpcServer *grpc.Server
+	books      pb.BookServiceClient
+}
+
+func newShutdownEnv(t *testing.T, handler http.Handler, repo *gatedBookRepository) *shutdownEnv {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	env := &shutdownEnv{httpServer: &http.Server{Handler: handler}, httpURL: "http://" + lis.Addr().String()}
+	go env.httpServer.Serve(lis)
+
+	grpcLis := bufconn.Listen(1024 * 1024)
+	env.grpcServer = grpc.NewServer()
+	pb.RegisterBookServiceServer(env.grpcServer, rpc.NewBookServiceServer(usecases.NewBookUsecase(repo)))
+	go env.grpcServer.Serve(grpcLis)
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return grpcLis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	env.books = pb.NewBookServiceClient(conn)
+
+	t.Cleanup(func() {
+		conn.Close()
+		env.grpcServer.Stop()
+		env.httpServer.Close()
+	})
+	return env
+}
+
+// noDeadline never ends the drain.
+func noDeadline(time.Duration) <-chan time.Time { return nil }
+
+func TestLifecycle_NotReadyBeforeDrainDelay(t *testing.T) {
+	env := newShutdownEnv(t, http.NotFoundHandler(), &gatedBookRepository{})
+
+	var (
+		readyz   []int
+		notReady bool
+		slept    time.Duration
+	)
+	checker := health.NewChecker(time.Second)
+	lc := lifecycle.New(lifecycle.WithClock(func(d time.Duration) {
+		rec := httptest.NewRecorder()
+		checker.ReadinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		readyz = append(readyz, rec.Code)
+		assert.True(t, notReady, "not-ready hooks run before the drain delay")
+		slept = d
+	}, noDeadline))
+	checker.Register("lifecycle", lc.ReadinessCheck)
+	lc.OnNotReady(func() { notReady = true })
+
+	lc.SetReady()
+	rec := httptest.NewRecorder()
+	checker.ReadinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	require.NoError(t, lc.Shutdown(config.ShutdownConfig{DrainDelay: 5 * time.Second, Timeout: time.Second},
+		env.httpServer, env.grpcServer))
+	assert.Equal(t, []int{http.StatusServiceUnavailable}, readyz)
+	assert.Equal(t, 5*time.Second, slept)
+}
+
+func TestLifecycle_InFlightRequestFinishes(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	env := newShutdownEnv(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "done")
+	}), &gatedBookRepository{})
+	env.httpServer.RegisterOnShutdown(func() { close(release) })
+
+	type result struct {
+		body string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(env.httpURL)
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		results <- result{body: string(body), err: err}
+	}()
+	<-started
+
+	lc := lifecycle.New(lifecycle.WithClock(func(time.Duration) {}, noDeadline))
+	require.NoError(t, lc.Shutdown(config.ShutdownConfig{Timeout: time.Second}, env.httpServer, env.grpcServer))
+	got := <-results
+	require.NoError(t, got.err)
+	assert.Equal(t, "done", got.body)
+}
+
+func TestLifecycle_HungRPCIsStoppedAtDeadline(t *testing.T) {
+	repo := &gatedBookRepository{started: make(chan struct{})}
+	env := newShutdownEnv(t, http.NotFoundHandler(), repo)
+
+	rpcErr := make(chan error, 1)
+	go func() {
+		_, err := env.books.GetBook(context.Background(), &pb.BookId{Id: 1})
+		rpcErr <- err
+	}()
+	<-repo.started
+
+	deadline := make(chan time.Time)
+	var timeout time.Duration
+	lc := lifecycle.New(lifecycle.WithClock(func(time.Duration) {}, func(d time.Duration) <-chan time.Time {
+		timeout = d
+		return deadline
+	}))
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- lc.Shutdown(config.ShutdownConfig{Timeout: 20 * time.Second}, env.httpServer, env.grpcServer)
+	}()
+
+	select {
+	case <-shutdownErr:
+		t.Fatal("shutdown returned while an RPC was still running")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(deadline)
+	select {
+	case err := <-shutdownErr:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown didn't stop the gRPC server at the deadline")
+	}
+	assert.Equal(t, 20*time.Second, timeout)
+	err := <-rpcErr
+	assert.Contains(t, []codes.Code{codes.Unavailable, codes.Canceled}, status.Code(err), err)
+}
+
+func TestLifecycle_DatabaseClosesAfterServersStop(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		steps []string
+	)
+	record := func(step string) {
+		mu.Lock()
+		defer mu.Unlock()
+		steps = append(steps, step)
+	}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	repo := &gatedBookRepository{started: make(chan struct{}), gate: release, done: func() {
+		time.Sleep(20 * time.Millisecond)
+		record("rpc")
+	}}
+	env := newShutdownEnv(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		time.Sleep(20 * time.Millisecond)
+		record("request")
+	}), repo)
+	env.httpServer.RegisterOnShutdown(func() { close(release) })
+
+	go http.Get(env.httpURL)
+	go env.books.GetBook(context.Background(), &pb.BookId{Id: 1})
+	<-started
+	<-repo.started
+
+	lc := lifecycle.New(lifecycle.WithClock(func(time.Duration) {}, noDeadline))
+	lc.OnShutdown("database pool", func(context.Context) error {
+		record("database")
+		return nil
+	})
+	lc.OnShutdown("outbox relay", func(context.Context) error {
+		record("relay")
+		return nil
+	})
+	require.NoError(t, lc.Shutdown(config.ShutdownConfig{Timeout: time.Second}, env.httpServer, env.grpcServer))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, steps, 4)
+	assert.ElementsMatch(t, []string{"request", "rpc"}, steps[:2])
+	assert.Equal(t, []string{"relay", "database"}, steps[2:])
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListBooks_HTTPPaginationAndFilters(t *testing.T) {
+	uc := usecases.NewBookUsecase(testRepository())
+	handler := handlers.NewBookHandler(uc)
+
+	for _, year := range []int{1990, 2000, 2010} {
+		book := &models.Book{Title: "Paginated", Author: "Listing Author", BookYear: year}
+		assert.NoError(t, uc.AddBook(context.Background(), book))
+	}
+
+	get := func(target string) (*httptest.ResponseRecorder, []models.Book) {
+		rec := httptest.NewRecorder()
+		handler.GetBooks(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		var books []models.Book
+		json.NewDecoder(rec.Body).Decode(&books)
+		return rec, books
+	}
+
+	rec, books := get("/books?author=listing+author&sort=year&order=desc&limit=2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, books, 2) {
+		assert.Equal(t, 2010, books[0].BookYear)
+		assert.Equal(t, 2000, books[1].BookYear)
+	}
+	token := rec.Header().Get(handlers.NextPageTokenHeader)
+	assert.NotEmpty(t, token)
+
+	rec, books = get("/books?author=listing+author&sort=year&order=desc&limit=2&page_token=" + token)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, books, 1) {
+		assert.Equal(t, 1990, books[0].BookYear)
+	}
+	assert.Empty(t, rec.Header().Get(handlers.NextPageTokenHeader))
+
+	rec, _ = get("/books?sort=isbn")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = get("/books?limit=ten")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
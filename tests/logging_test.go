@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/logging"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// logLines decodes the JSON log records written to buf.
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if assert.NoError(t, json.Unmarshal([]byte(line), &rec), line) {
+			lines = append(lines, rec)
+		}
+	}
+	return lines
+}
+
+func TestLogging_HTTPMiddlewareCorrelatesRequest(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "debug", logging.FormatJSON)
+	assert.NoError(t, err)
+
+	uc := usecases.NewBookUsecase(&stubBookRepository{}, usecases.WithLogger(logger))
+	r := mux.NewRouter()
+	r.HandleFunc("/books", func(w http.ResponseWriter, r *http.Request) {
+		uc.AddBook(r.Context(), &models.Book{Title: "Dune", Author: "Frank Herbert", BookYear: 1965})
+		w.WriteHeader(http.StatusCreated)
+	}).Methods("POST")
+	r.Use(logging.Middleware(logger))
+
+	req := httptest.NewRequest("POST", "/books", nil)
+	req.Header.Set(logging.RequestIDHeader, "abc-123")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, "abc-123", rr.Header().Get(logging.RequestIDHeader))
+
+	lines := logLines(t, &buf)
+	if assert.NotEmpty(t, lines) {
+		for _, line := range lines {
+			assert.Equal(t, "abc-123", line["request_id"], line["msg"])
+		}
+		access := lines[len(lines)-1]
+		assert.Equal(t, "http request", access["msg"])
+		assert.Equal(t, "/books", access["route"])
+		assert.Equal(t, float64(http.StatusCreated), access["status"])
+		assert.Contains(t, access, "latency")
+	}
+}
+
+func TestLogging_HTTPMiddlewareReplacesUnusableID(t *testing.T) {
+	logger, err := logging.New(&bytes.Buffer{}, "info", logging.FormatText)
+	assert.NoError(t, err)
+	handler := logging.Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, sent := range []string{"", "has spaces\nand newlines", strings.Repeat("x", 200)} {
+		req := httptest.NewRequest("GET", "/books", nil)
+		req.Header.Set(logging.RequestIDHeader, sent)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		id := rr.Header().Get(logging.RequestIDHeader)
+		assert.Len(t, id, 32, "sent %q", sent)
+	}
+}
+
+func TestLogging_GRPCInterceptorPropagatesMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info", logging.FormatJSON)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(logging.RequestIDMetadata, "rpc-42"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/book.BookService/GetBook"}
+	var seen string
+	_, err = logging.UnaryServerInterceptor(logger)(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		seen = logging.RequestID(ctx)
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "rpc-42", seen)
+
+	lines := logLines(t, &buf)
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, "rpc-42", lines[0]["request_id"])
+		assert.Equal(t, "/book.BookService/GetBook", lines[0]["method"])
+		assert.Equal(t, "OK", lines[0]["code"])
+	}
+}
+
+func TestLogging_RejectsUnknownLevel(t *testing.T) {
+	_, err := logging.New(&bytes.Buffer{}, "verbose", logging.FormatJSON)
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"database/sql"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/config"
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/memory"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/postgres"
+	_ "github.com/lib/pq"
+)
+
+// testConfigPath is resolved relative to the tests directory; DATABASE_DSN overrides it.
+const testConfigPath = "../configs/config.yaml"
+
+var (
+	repoOnce       sync.Once
+	testRepo       repository.BookRepository
+	testAuthorRepo repository.AuthorRepository
+	db             *sql.DB
+)
+
+// testRepository returns the repository shared by the tests in this package:
+// the in-memory adapter by default, or Postgres when TEST_STORAGE=postgres.
+func testRepository() repository.BookRepository {
+	repoOnce.Do(func() {
+		if os.Getenv("TEST_STORAGE") != config.StoragePostgres {
+			books := memory.NewBookRepository()
+			testRepo, testAuthorRepo = books, memory.NewAuthorRepository(books)
+			return
+		}
+
+		cfg, err := config.Load(testConfigPath)
+		if err != nil {
+			panic(err)
+		}
+		db, err = sql.Open("postgres", cfg.Database.DSN)
+		if err != nil {
+			panic(err)
+		}
+		testRepo, testAuthorRepo = postgres.NewBookRepository(db), postgres.NewAuthorRepository(db)
+	})
+	return testRepo
+}
+
+// testAuthorRepository returns the author repository sharing storage with testRepository.
+func testAuthorRepository() repository.AuthorRepository {
+	testRepository()
+	return testAuthorRepo
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if db != nil {
+		db.Close()
+	}
+	os.Exit(code)
+}
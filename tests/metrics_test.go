@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/metrics"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scrape returns the exposition text served by m.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rr.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_HTTPMiddlewareLabelsRouteTemplate(t *testing.T) {
+	m := metrics.New()
+	r := mux.NewRouter()
+	r.HandleFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}).Methods("GET")
+	r.Use(m.Middleware)
+
+	for _, id := range []string{"1", "2"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/books/"+id, nil))
+	}
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `books_http_requests_total{code="404",method="GET",route="/books/{id}"} 2`)
+	assert.Contains(t, out, `books_http_request_duration_seconds_count{code="404",method="GET",route="/books/{id}"} 2`)
+}
+
+func TestMetrics_GRPCInterceptorRecordsStatusCode(t *testing.T) {
+	m := metrics.New()
+	intercept := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/book.BookService/GetBook"}
+
+	intercept(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, status.Error(codes.NotFound, "book 1 not found")
+	})
+
+	assert.Contains(t, scrape(t, m), `books_grpc_requests_total{code="NotFound",method="GetBook",service="book.BookService"} 1`)
+}
+
+func TestMetrics_UsecaseBusinessCounters(t *testing.T) {
+	m := metrics.New()
+	repo := &stubBookRepository{}
+	uc := usecases.NewBookUsecase(repo, usecases.WithMetrics(m))
+	ctx := context.Background()
+
+	assert.NoError(t, uc.AddBook(ctx, &models.Book{Title: "Dune", Author: "Frank Herbert", BookYear: 1965}))
+	assert.NoError(t, uc.DeleteBook(ctx, 1, 1))
+	assert.Error(t, uc.AddBook(ctx, &models.Book{Author: "Nobody", BookYear: 2000}))
+	repo.err = errs.Unavailable(errors.New("connection refused"), "database unavailable")
+	assert.Error(t, uc.DeleteBook(ctx, 1, 1))
+
+	out := scrape(t, m)
+	assert.Contains(t, out, "books_created_total 1")
+	assert.Contains(t, out, "books_deleted_total 1")
+	assert.Contains(t, out, `books_usecase_failures_total{kind="validation_failed",operation="add_book"} 1`)
+	assert.Contains(t, out, `books_usecase_failures_total{kind="unavailable",operation="delete_book"} 1`)
+}
@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Dias221467/MicroServices/internal/migrate"
+	"github.com/Dias221467/MicroServices/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate_LoadEmbedded(t *testing.T) {
+	loaded, err := migrate.Load(migrations.FS)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, loaded) {
+		assert.Equal(t, uint(1), loaded[0].Version)
+		assert.Equal(t, "book", loaded[0].Name)
+		assert.Contains(t, loaded[0].Up, "CREATE TABLE")
+		assert.Contains(t, loaded[0].Down, "DROP TABLE")
+	}
+	for i := 1; i < len(loaded); i++ {
+		assert.Less(t, loaded[i-1].Version, loaded[i].Version)
+	}
+}
+
+func TestMigrate_LoadRejectsMissingUp(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_init.up.sql":     {Data: []byte("CREATE TABLE a (id INT);")},
+		"000002_broken.down.sql": {Data: []byte("DROP TABLE b;")},
+		"README.md":              {Data: []byte("ignored")},
+	}
+
+	_, err := migrate.Load(fsys)
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/memory"
+	"github.com/Dias221467/MicroServices/internal/metrics"
+	"github.com/Dias221467/MicroServices/internal/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder collects the events handed to it on a bus, failing while fail
+// is set.
+type recorder struct {
+	mu     sync.Mutex
+	events []*models.BookEvent
+	fail   bool
+}
+
+func (r *recorder) handle(_ context.Context, event *models.BookEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("consumer unavailable")
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recorder) types() []models.BookEventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]models.BookEventType, len(r.events))
+	for i, event := range r.events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func TestOutboxRelay_PublishesToBus(t *testing.T) {
+	ctx := context.Background()
+	books := memory.NewBookRepository()
+	bus := outbox.NewBus()
+	rec := &recorder{}
+	unsubscribe := bus.Subscribe(rec.handle)
+	relay := outbox.NewRelay(memory.NewOutboxRepository(books), bus)
+
+	book := &models.Book{Title: "Relayed", Author: "Test Author", BookYear: 2024}
+	require.NoError(t, books.Create(ctx, book))
+	require.NoError(t, books.Delete(ctx, book.ID, 0))
+
+	n, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "the delete waits for the create")
+	n, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, []models.BookEventType{models.BookCreated, models.BookDeleted}, rec.types())
+
+	unsubscribe()
+	require.NoError(t, books.Create(ctx, &models.Book{Title: "Unheard", Author: "Test Author", BookYear: 2024}))
+	_, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rec.types(), 2)
+}
+
+func TestOutboxRelay_RetriesFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	books := memory.NewBookRepository()
+	bus := outbox.NewBus()
+	rec := &recorder{fail: true}
+	bus.Subscribe(rec.handle)
+	m := metrics.New()
+	relay := outbox.NewRelay(memory.NewOutboxRepository(books), bus,
+		outbox.WithBackoff(20*time.Millisecond, time.Second),
+		outbox.WithMetrics(m),
+	)
+
+	require.NoError(t, books.Create(ctx, &models.Book{Title: "Retried", Author: "Test Author", BookYear: 2024}))
+	n, err := relay.RelayOnce(ctx)
+	require.NoError(t, err, "failed publishes are retried, not reported")
+	assert.Equal(t, 1, n)
+
+	rec.mu.Lock()
+	rec.fail = false
+	rec.mu.Unlock()
+	n, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n, "not due before the backoff")
+
+	time.Sleep(30 * time.Millisecond)
+	n, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, rec.types(), 1)
+	assert.Equal(t, 1, rec.events[0].Attempts)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `books_outbox_publish_failures_total{type="BookCreated"} 1`)
+	assert.Contains(t, body, `books_outbox_events_published_total{type="BookCreated"} 1`)
+}
+
+func TestOutboxRelay_RunPublishesUntilStopped(t *testing.T) {
+	ctx := context.Background()
+	books := memory.NewBookRepository()
+	bus := outbox.NewBus()
+	rec := &recorder{}
+	bus.Subscribe(rec.handle)
+	relay := outbox.NewRelay(memory.NewOutboxRepository(books), bus)
+
+	runCtx, stop := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		relay.Run(runCtx, 5*time.Millisecond)
+	}()
+
+	require.NoError(t, books.Create(ctx, &models.Book{Title: "Background", Author: "Test Author", BookYear: 2024}))
+	assert.Eventually(t, func() bool { return len(rec.types()) == 1 }, time.Second, 5*time.Millisecond)
+	stop()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Run didn't return after its context was cancelled")
+	}
+}
+
+func TestOutboxFileSink_AppendsJSONLines(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := outbox.NewFileSink(path)
+	require.NoError(t, err)
+	for i, typ := range []models.BookEventType{models.BookCreated, models.BookUpdated} {
+		require.NoError(t, sink.Publish(ctx, &models.BookEvent{ID: i + 1, Type: typ, BookID: 7, Version: i + 1}))
+	}
+	require.NoError(t, sink.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var events []models.BookEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event models.BookEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, events, 2)
+	assert.Equal(t, models.BookUpdated, events[1].Type)
+	assert.Equal(t, 2, events[1].Version)
+}
+
+func TestOutboxKafkaRESTSink_ProducesKeyedRecords(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu      sync.Mutex
+		records []json.RawMessage
+		reply   = `{"offsets":[{"partition":0,"offset":41,"error_code":null,"error":null}]}`
+	)
+	// A stand-in for the REST Proxy's produce endpoint.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/topics/books.events" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Content-Type") != "application/vnd.kafka.json.v2+json" {
+			http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+			return
+		}
+		var body struct {
+			Records []json.RawMessage `json:"records"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		records = append(records, body.Records...)
+		resp := reply
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/vnd.kafka.v2+json")
+		w.Write([]byte(resp))
+	}))
+	defer proxy.Close()
+
+	sink := outbox.NewKafkaRESTSink(proxy.URL+"/", "books.events", nil)
+	require.NoError(t, sink.Publish(ctx, &models.BookEvent{ID: 3, Type: models.BookCreated, BookID: 12, Version: 1}))
+	require.Len(t, records, 1)
+	var record struct {
+		Key   string           `json:"key"`
+		Value models.BookEvent `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal(records[0], &record))
+	assert.Equal(t, "12", record.Key, "records are keyed by book")
+	assert.Equal(t, 3, record.Value.ID)
+	assert.Equal(t, models.BookCreated, record.Value.Type)
+
+	mu.Lock()
+	reply = `{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"leader not available"}]}`
+	mu.Unlock()
+	err := sink.Publish(ctx, &models.BookEvent{ID: 4, Type: models.BookUpdated, BookID: 12, Version: 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "leader not available")
+
+	err = outbox.NewKafkaRESTSink(proxy.URL, "unknown", nil).Publish(ctx, &models.BookEvent{ID: 5})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
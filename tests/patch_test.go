@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// doPatch sends a raw patch document with the given media type and If-Match.
+func doPatch(t *testing.T, url, mediaType, ifMatch, patch string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(patch))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", mediaType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestIntegration_PatchBook(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	resp := doJSON(t, http.MethodPost, server.URL+"/books", &models.Book{
+		Title: "Persuasion", Author: "Jane Austen", BookYear: 1817, Publisher: "John Murray",
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var book models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	url := server.URL + "/books/" + strconv.Itoa(book.ID)
+
+	resp = doPatch(t, url, handlers.MergePatchType, `"1"`, `{"pages": 249, "publisher": null}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+	book = models.Book{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	assert.Equal(t, 249, book.Pages)
+	assert.Empty(t, book.Publisher, "null removes a field")
+	assert.Equal(t, "Persuasion", book.Title)
+
+	resp = doPatch(t, url, handlers.JSONPatchType, `"2"`, `[
+		{"op": "test", "path": "/title", "value": "Persuasion"},
+		{"op": "replace", "path": "/title", "value": "Persuasion (Annotated)"},
+		{"op": "add", "path": "/contributors/-", "value": {"name": "Robert Morrison", "role": "editor"}}
+	]`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	assert.Equal(t, "Persuasion (Annotated)", book.Title)
+	assert.Len(t, book.Contributors, 2)
+	assert.Equal(t, "Jane Austen", book.Author)
+	assert.Equal(t, 3, book.Version)
+
+	cases := []struct {
+		name, mediaType, ifMatch, patch string
+		want                            int
+	}{
+		{"no If-Match", handlers.MergePatchType, "", `{"pages": 1}`, http.StatusPreconditionRequired},
+		{"stale If-Match", handlers.MergePatchType, `"1"`, `{"pages": 1}`, http.StatusPreconditionFailed},
+		{"plain JSON", "application/json", `"3"`, `{"pages": 1}`, http.StatusUnsupportedMediaType},
+		{"read-only field", handlers.MergePatchType, `"3"`, `{"id": 12345}`, http.StatusBadRequest},
+		{"unknown field", handlers.MergePatchType, `"3"`, `{"colour": "red"}`, http.StatusBadRequest},
+		{"invalid value", handlers.MergePatchType, `"3"`, `{"year": 0}`, http.StatusBadRequest},
+		{"malformed patch", handlers.JSONPatchType, `"3"`, `{"op": "add"}`, http.StatusBadRequest},
+		{"failed test", handlers.JSONPatchType, `"3"`, `[{"op": "test", "path": "/title", "value": "Emma"}]`, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		resp := doPatch(t, url, tc.mediaType, tc.ifMatch, tc.patch)
+		assert.Equal(t, tc.want, resp.StatusCode, tc.name)
+	}
+
+	resp = doPatch(t, url, handlers.MergePatchType, `"3"`, `{}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"3"`, resp.Header.Get("ETag"), "an empty patch doesn't create a version")
+}
+
+func TestGRPC_UpdateBookWithFieldMask(t *testing.T) {
+	client := setupGRPCClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &pb.Book{Title: "Masked", Author: "Test Author", Year: 2024, Publisher: "Kept"})
+	require.NoError(t, err)
+
+	updated, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{
+		Book:       &pb.Book{Id: created.GetId(), Version: created.GetVersion(), Title: "Masked Again", Pages: 99},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Masked Again", updated.GetTitle())
+	assert.Equal(t, int32(0), updated.GetPages(), "fields outside the mask are left alone")
+	assert.Equal(t, "Kept", updated.GetPublisher())
+	assert.Equal(t, "Test Author", updated.GetAuthor())
+
+	_, err = client.UpdateBook(ctx, &pb.UpdateBookRequest{
+		Book:       &pb.Book{Id: created.GetId(), Version: updated.GetVersion()},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"created_at"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/poll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll_BackoffDoublesUpToMax(t *testing.T) {
+	b := poll.Backoff{Initial: time.Second, Max: 5 * time.Second}
+	var delays []time.Duration
+	for attempts := 1; attempts <= 5; attempts++ {
+		delays = append(delays, b.Delay(attempts))
+	}
+	assert.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+	}, delays)
+}
+
+// runLoop runs a loop over the given batch sizes, a negative size standing
+// for a failed batch, until it idles after the last one. The interval is
+// long enough that reaching the end means nothing waited for a tick.
+func runLoop(t *testing.T, batches ...int) (idles int, failures []error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		poll.Loop{
+			Interval:  time.Hour,
+			BatchSize: 3,
+			Once: func(context.Context) (int, error) {
+				n := batches[calls]
+				calls++
+				if n < 0 {
+					return 0, errors.New("claim failed")
+				}
+				return n, nil
+			},
+			OnError: func(_ context.Context, err error) { failures = append(failures, err) },
+			Idle: func(context.Context) {
+				idles++
+				if calls == len(batches) {
+					cancel()
+				}
+			},
+		}.Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the loop waited for a tick before draining")
+	}
+	assert.Equal(t, len(batches), calls)
+	return idles, failures
+}
+
+func TestPoll_LoopDrainsFullBatchesBeforeWaiting(t *testing.T) {
+	idles, failures := runLoop(t, 3, 3, 1)
+	assert.Equal(t, 1, idles, "only the short batch idles")
+	assert.Empty(t, failures)
+
+	idles, failures = runLoop(t, 3, -1)
+	assert.Equal(t, 1, idles, "a failed batch waits for the next tick")
+	assert.Len(t, failures, 1)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/repository"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/memory"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/postgres"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/repotest"
+	"github.com/Dias221467/MicroServices/internal/migrate"
+	"github.com/Dias221467/MicroServices/migrations"
+)
+
+func TestBookRepositoryContract_Memory(t *testing.T) {
+	repotest.RunBookRepositoryContract(t, func(t *testing.T) repository.BookRepository {
+		return memory.NewBookRepository()
+	})
+}
+
+func TestAuthorRepositoryContract_Memory(t *testing.T) {
+	repotest.RunAuthorRepositoryContract(t, func(t *testing.T) (repository.BookRepository, repository.AuthorRepository) {
+		books := memory.NewBookRepository()
+		return books, memory.NewAuthorRepository(books)
+	})
+}
+
+func TestOutboxRepositoryContract_Memory(t *testing.T) {
+	repotest.RunOutboxRepositoryContract(t, func(t *testing.T) (repository.BookRepository, repository.OutboxRepository) {
+		books := memory.NewBookRepository()
+		return books, memory.NewOutboxRepository(books)
+	})
+}
+
+func TestWebhookRepositoryContract_Memory(t *testing.T) {
+	repotest.RunWebhookRepositoryContract(t, func(t *testing.T) repository.WebhookRepository {
+		return memory.NewWebhookRepository()
+	})
+}
+
+// testDatabaseDSNEnv names the database the Postgres contract suite runs
+// against. The suite truncates every table, so it never falls back to the
+// service's own DSN.
+const testDatabaseDSNEnv = "TEST_DATABASE_DSN"
+
+func TestBookRepositoryContract_Postgres(t *testing.T) {
+	dsn := os.Getenv(testDatabaseDSNEnv)
+	if dsn == "" {
+		t.Skip("set " + testDatabaseDSNEnv + " to a disposable database, whose tables are truncated, or run make test-postgres")
+	}
+
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	migrator, err := migrate.New(conn, migrations.FS)
+	if err == nil {
+		err = migrator.Up(context.Background())
+	}
+	if err != nil {
+		t.Fatalf("Failed to migrate the database: %v", err)
+	}
+
+	reset := func(t *testing.T) {
+		if _, err := conn.Exec(`TRUNCATE books, book_authors, authors, book_history, outbox, webhooks, webhook_deliveries RESTART IDENTITY`); err != nil {
+			t.Fatalf("Failed to reset tables: %v", err)
+		}
+	}
+	t.Run("Books", func(t *testing.T) {
+		repotest.RunBookRepositoryContract(t, func(t *testing.T) repository.BookRepository {
+			reset(t)
+			return postgres.NewBookRepository(conn)
+		})
+	})
+	t.Run("Authors", func(t *testing.T) {
+		repotest.RunAuthorRepositoryContract(t, func(t *testing.T) (repository.BookRepository, repository.AuthorRepository) {
+			reset(t)
+			return postgres.NewBookRepository(conn), postgres.NewAuthorRepository(conn)
+		})
+	})
+	t.Run("Outbox", func(t *testing.T) {
+		repotest.RunOutboxRepositoryContract(t, func(t *testing.T) (repository.BookRepository, repository.OutboxRepository) {
+			reset(t)
+			return postgres.NewBookRepository(conn), postgres.NewOutboxRepository(conn)
+		})
+	})
+	t.Run("Webhooks", func(t *testing.T) {
+		repotest.RunWebhookRepositoryContract(t, func(t *testing.T) repository.WebhookRepository {
+			reset(t)
+			return postgres.NewWebhookRepository(conn)
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/errs"
+	"github.com/Dias221467/MicroServices/internal/tracing"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_UsecaseSpansRecordFailures(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	repo := &stubBookRepository{err: errs.Unavailable(errors.New("connection refused"), "database unavailable")}
+	uc := usecases.NewBookUsecase(repo, usecases.WithTracerProvider(tp))
+
+	uc.DeleteBook(context.Background(), 1, 1)
+	repo.err = errs.NotFound("book 2 not found")
+	uc.GetBookByID(context.Background(), 2)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "BookUsecase.DeleteBook", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "BookUsecase.GetBookByID", spans[1].Name())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code, "client errors don't fail the span")
+	}
+}
+
+func TestTracing_HTTPContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	uc := usecases.NewBookUsecase(&stubBookRepository{}, usecases.WithTracerProvider(tp))
+
+	r := mux.NewRouter()
+	r.HandleFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		uc.GetBookByID(r.Context(), 1)
+	}).Methods("GET")
+	r.Use(otelmux.Middleware("books",
+		otelmux.WithTracerProvider(tp),
+		otelmux.WithPropagators(propagation.TraceContext{}),
+	))
+
+	req := httptest.NewRequest("GET", "/books/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a1ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		usecase, server := spans[0], spans[1]
+		assert.Equal(t, "/books/{id}", server.Name())
+		assert.Equal(t, "4bf92f3577b34da6a1ce929d0e0e4736", server.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+		assert.Equal(t, server.SpanContext().SpanID(), usecase.Parent().SpanID())
+	}
+}
+
+func TestTracing_SetupRejectsUnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), "books", tracing.Config{Exporter: "zipkin"})
+	assert.Error(t, err)
+
+	shutdown, err := tracing.Setup(context.Background(), "books", tracing.Config{Exporter: tracing.ExporterNone})
+	if assert.NoError(t, err) {
+		assert.NoError(t, shutdown(context.Background()))
+	}
+}
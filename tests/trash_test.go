@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	pb "github.com/Dias221467/MicroServices/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// trashIDs returns the IDs of the books in the trash whose title contains title.
+func trashIDs(t *testing.T, baseURL, title string) []int {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, baseURL+"/books/trash?title_contains="+title, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var books []models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&books))
+	ids := []int{}
+	for _, book := range books {
+		assert.NotNil(t, book.DeletedAt)
+		ids = append(ids, book.ID)
+	}
+	return ids
+}
+
+func TestIntegration_TrashAndRestore(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	resp := doJSON(t, http.MethodPost, server.URL+"/books", &models.Book{Title: "Trash Panda Tales", Author: "Test Author", BookYear: 2024})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var book models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	url := server.URL + "/books/" + strconv.Itoa(book.ID)
+
+	resp = doJSON(t, http.MethodDelete, url, nil, "If-Match", handlers.ETag(book.Version))
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, doJSON(t, http.MethodGet, url, nil).StatusCode)
+	assert.Equal(t, []int{book.ID}, trashIDs(t, server.URL, "Trash+Panda"))
+
+	resp = doJSON(t, http.MethodPost, url+":restore", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"3"`, resp.Header.Get("ETag"), "deleting and restoring each make a new version")
+	var restored models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&restored))
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, "Trash Panda Tales", restored.Title)
+
+	assert.Equal(t, http.StatusOK, doJSON(t, http.MethodGet, url, nil).StatusCode)
+	assert.Empty(t, trashIDs(t, server.URL, "Trash+Panda"))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, http.MethodPost, url+":restore", nil).StatusCode)
+}
+
+func TestIntegration_RestoreRejectsTakenISBN(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	first := &models.Book{Title: "Reissued", Author: "Test Author", BookYear: 2001, ISBN: "9780000000019"}
+	resp := doJSON(t, http.MethodPost, server.URL+"/books", first)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(first))
+	url := server.URL + "/books/" + strconv.Itoa(first.ID)
+	require.Equal(t, http.StatusNoContent, doJSON(t, http.MethodDelete, url, nil, "If-Match", "*").StatusCode)
+
+	resp = doJSON(t, http.MethodPost, server.URL+"/books", &models.Book{Title: "Reissued, 2nd ed.", Author: "Test Author", BookYear: 2020, ISBN: first.ISBN})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "a trashed book doesn't hold on to its ISBN")
+
+	resp = doJSON(t, http.MethodPost, url+":restore", nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, []int{first.ID}, trashIDs(t, server.URL, "Reissued"))
+}
+
+func TestGRPC_TrashAndRestore(t *testing.T) {
+	client := setupGRPCClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &pb.Book{Title: "gRPC Trash Book", Author: "Test Author", Year: 2024})
+	require.NoError(t, err)
+	_, err = client.DeleteBook(ctx, &pb.BookId{Id: created.GetId(), Version: created.GetVersion()})
+	require.NoError(t, err)
+
+	trash, err := client.ListDeletedBooks(ctx, &pb.ListBooksRequest{TitleContains: "gRPC Trash"})
+	require.NoError(t, err)
+	require.Len(t, trash.GetBooks(), 1)
+	assert.NotNil(t, trash.GetBooks()[0].GetDeletedAt())
+
+	restored, err := client.RestoreBook(ctx, &pb.BookId{Id: created.GetId()})
+	require.NoError(t, err)
+	assert.Nil(t, restored.GetDeletedAt())
+	assert.Equal(t, created.GetVersion()+2, restored.GetVersion())
+
+	_, err = client.RestoreBook(ctx, &pb.BookId{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,377 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/MicroServices/internal/audit"
+	"github.com/Dias221467/MicroServices/internal/domain/models"
+	"github.com/Dias221467/MicroServices/internal/interfaces/adapters/memory"
+	"github.com/Dias221467/MicroServices/internal/interfaces/handlers"
+	"github.com/Dias221467/MicroServices/internal/interfaces/router"
+	"github.com/Dias221467/MicroServices/internal/metrics"
+	"github.com/Dias221467/MicroServices/internal/outbox"
+	"github.com/Dias221467/MicroServices/internal/usecases"
+	"github.com/Dias221467/MicroServices/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookEnv is an API server on its own storage, with the relay and
+// dispatcher that the service runs in the background.
+type webhookEnv struct {
+	server     *httptest.Server
+	relay      *outbox.Relay
+	dispatcher *webhook.Dispatcher
+}
+
+func newWebhookEnv(t *testing.T, opts ...webhook.Option) *webhookEnv {
+	books := memory.NewBookRepository()
+	webhooks := memory.NewWebhookRepository()
+	bookUsecase := usecases.NewBookUsecase(books)
+	server := httptest.NewServer(router.New(handlers.NewBookHandler(bookUsecase),
+		router.WithWebhookHandler(handlers.NewWebhookHandler(usecases.NewWebhookUsecase(webhooks, bookUsecase))),
+		router.WithMiddleware(audit.Middleware),
+	))
+	t.Cleanup(server.Close)
+
+	// The receivers in these tests listen on loopback.
+	opts = append([]webhook.Option{webhook.WithClient(webhook.NewClient(5*time.Second, true))}, opts...)
+	bus := outbox.NewBus()
+	dispatcher := webhook.NewDispatcher(webhooks, opts...)
+	bus.Subscribe(dispatcher.Publish)
+	return &webhookEnv{
+		server:     server,
+		relay:      outbox.NewRelay(memory.NewOutboxRepository(books), bus),
+		dispatcher: dispatcher,
+	}
+}
+
+// deliver relays every pending event and makes one round of delivery
+// attempts, returning how many deliveries were attempted.
+func (e *webhookEnv) deliver(t *testing.T) int {
+	t.Helper()
+	ctx := context.Background()
+	for {
+		n, err := e.relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+	n, err := e.dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	return n
+}
+
+func (e *webhookEnv) register(t *testing.T, url string, types ...models.BookEventType) models.Webhook {
+	t.Helper()
+	resp := doJSON(t, http.MethodPost, e.server.URL+"/webhooks",
+		map[string]any{"url": url, "event_types": types, "secret": "whsec_test"})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created models.Webhook
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	return created
+}
+
+func (e *webhookEnv) deliveries(t *testing.T, id int) []models.WebhookDelivery {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, fmt.Sprintf("%s/webhooks/%d/deliveries", e.server.URL, id), nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var deliveries []models.WebhookDelivery
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&deliveries))
+	return deliveries
+}
+
+// receiver is a webhook endpoint that checks signatures and answers with
+// status.
+type receiver struct {
+	mu     sync.Mutex
+	status int
+	events []models.BookEvent
+	ids    []string
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := webhook.Verify("whsec_test", r.Header.Get(webhook.SignatureHeader), body, time.Minute, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var event models.BookEvent
+	if err := json.Unmarshal(body, &event); err != nil || string(event.Type) != r.Header.Get(webhook.EventHeader) {
+		http.Error(w, "bad event", http.StatusBadRequest)
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.status != 0 {
+		w.WriteHeader(rc.status)
+		return
+	}
+	rc.events = append(rc.events, event)
+	rc.ids = append(rc.ids, r.Header.Get(webhook.DeliveryHeader))
+}
+
+func (rc *receiver) setStatus(status int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.status = status
+}
+
+func TestWebhooks_DeliverSignedBookEvents(t *testing.T) {
+	env := newWebhookEnv(t)
+	rc := &receiver{}
+	endpoint := httptest.NewServer(rc)
+	defer endpoint.Close()
+
+	created := env.register(t, endpoint.URL, models.BookCreated, models.BookDeleted)
+	assert.NotZero(t, created.ID)
+	assert.Empty(t, created.Secret, "the secret is write-only")
+	everything := env.register(t, endpoint.URL+"/all")
+
+	resp := doJSON(t, http.MethodPost, env.server.URL+"/books",
+		models.Book{Title: "Hooked", Author: "Test Author", BookYear: 2024})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var book models.Book
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&book))
+	resp.Body.Close()
+	book.Title = "Hooked, Revised"
+	resp = doJSON(t, http.MethodPut, fmt.Sprintf("%s/books/%d", env.server.URL, book.ID), book,
+		"If-Match", handlers.ETag(book.Version))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = doJSON(t, http.MethodDelete, fmt.Sprintf("%s/books/%d", env.server.URL, book.ID), nil,
+		"If-Match", handlers.ETag(book.Version+1))
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	// Each round delivers the next event of the book to each webhook.
+	for env.deliver(t) > 0 {
+	}
+	rc.mu.Lock()
+	require.Len(t, rc.events, 5)
+	var types []models.BookEventType
+	for _, event := range rc.events {
+		assert.Equal(t, book.ID, event.BookID)
+		types = append(types, event.Type)
+	}
+	rc.mu.Unlock()
+	assert.ElementsMatch(t, []models.BookEventType{
+		models.BookCreated, models.BookDeleted,
+		models.BookCreated, models.BookUpdated, models.BookDeleted,
+	}, types)
+
+	deliveries := env.deliveries(t, created.ID)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, models.BookDeleted, deliveries[0].EventType, "newest first")
+	assert.Equal(t, models.BookCreated, deliveries[1].EventType)
+	for _, d := range deliveries {
+		assert.Equal(t, models.DeliverySucceeded, d.Status)
+		assert.Equal(t, http.StatusOK, d.ResponseStatus)
+		assert.Equal(t, 1, d.Attempts)
+		assert.NotNil(t, d.DeliveredAt)
+	}
+	assert.Len(t, env.deliveries(t, everything.ID), 3)
+
+	assert.Zero(t, env.deliver(t), "every event is delivered once")
+}
+
+func TestWebhooks_RetryWithBackoffThenDisable(t *testing.T) {
+	m := metrics.New()
+	env := newWebhookEnv(t,
+		webhook.WithBackoff(20*time.Millisecond, 40*time.Millisecond),
+		webhook.WithDisableAfter(3),
+		webhook.WithMetrics(m),
+	)
+	rc := &receiver{status: http.StatusServiceUnavailable}
+	endpoint := httptest.NewServer(rc)
+	defer endpoint.Close()
+	hook := env.register(t, endpoint.URL)
+
+	resp := doJSON(t, http.MethodPost, env.server.URL+"/books",
+		models.Book{Title: "Unreachable", Author: "Test Author", BookYear: 2024})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	require.Equal(t, 1, env.deliver(t))
+	assert.Zero(t, env.deliver(t), "not due before the backoff")
+	for attempt := 2; attempt <= 3; attempt++ {
+		require.Eventually(t, func() bool { return env.deliver(t) == 1 }, time.Second, 5*time.Millisecond)
+	}
+
+	deliveries := env.deliveries(t, hook.ID)
+	require.Len(t, deliveries, 1)
+	d := deliveries[0]
+	assert.Equal(t, models.DeliveryPending, d.Status)
+	assert.Equal(t, 3, d.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, d.ResponseStatus)
+	assert.Contains(t, d.LastError, "503")
+
+	resp = doJSON(t, http.MethodGet, fmt.Sprintf("%s/webhooks/%d", env.server.URL, hook.ID), nil)
+	var disabled models.Webhook
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&disabled))
+	resp.Body.Close()
+	require.NotNil(t, disabled.DisabledAt, "disabled after repeated failures")
+	assert.Equal(t, 3, disabled.FailureCount)
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, env.deliver(t), "disabled webhooks get no deliveries")
+
+	rc.setStatus(0)
+	resp = doJSON(t, http.MethodPost, fmt.Sprintf("%s/webhooks/%d:enable", env.server.URL, hook.ID), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var enabled models.Webhook
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&enabled))
+	resp.Body.Close()
+	assert.Nil(t, enabled.DisabledAt)
+	assert.Zero(t, enabled.FailureCount)
+
+	require.Equal(t, 1, env.deliver(t))
+	d = env.deliveries(t, hook.ID)[0]
+	assert.Equal(t, models.DeliverySucceeded, d.Status)
+	assert.Equal(t, 4, d.Attempts)
+	assert.Equal(t, http.StatusOK, d.ResponseStatus)
+	assert.Equal(t, []string{strconv.Itoa(d.ID)}, rc.ids, "the delivery ID is stable across retries")
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `books_webhook_delivery_failures_total{type="BookCreated"} 3`)
+	assert.Contains(t, body, `books_webhook_deliveries_total{type="BookCreated"} 1`)
+	assert.Contains(t, body, `books_webhook_disabled_total 1`)
+}
+
+func TestWebhooks_GiveUpAfterMaxAttempts(t *testing.T) {
+	env := newWebhookEnv(t, webhook.WithBackoff(time.Millisecond, time.Millisecond), webhook.WithMaxAttempts(2))
+	endpoint := httptest.NewServer(&receiver{status: http.StatusInternalServerError})
+	defer endpoint.Close()
+	hook := env.register(t, endpoint.URL)
+
+	resp := doJSON(t, http.MethodPost, env.server.URL+"/books",
+		models.Book{Title: "Rejected", Author: "Test Author", BookYear: 2024})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	require.Equal(t, 1, env.deliver(t))
+	require.Eventually(t, func() bool { return env.deliver(t) == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	assert.Zero(t, env.deliver(t))
+
+	d := env.deliveries(t, hook.ID)[0]
+	assert.Equal(t, models.DeliveryFailed, d.Status)
+	assert.Equal(t, 2, d.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, d.ResponseStatus)
+	assert.Nil(t, d.NextAttemptAt)
+}
+
+func TestWebhooks_RefusePrivateTargetsAndRedirects(t *testing.T) {
+	endpoint := httptest.NewServer(&receiver{})
+	defer endpoint.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(endpoint.URL, http.StatusFound))
+	defer redirect.Close()
+
+	// The default client: the loopback receiver is out of reach.
+	env := newWebhookEnv(t, webhook.WithClient(webhook.NewClient(5*time.Second, false)))
+	hook := env.register(t, endpoint.URL)
+	resp := doJSON(t, http.MethodPost, env.server.URL+"/books",
+		models.Book{Title: "Probe", Author: "Test Author", BookYear: 2024})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+	require.Equal(t, 1, env.deliver(t))
+
+	d := env.deliveries(t, hook.ID)[0]
+	assert.Equal(t, models.DeliveryPending, d.Status)
+	assert.Zero(t, d.ResponseStatus, "nothing about the target leaks")
+	assert.Contains(t, d.LastError, webhook.ErrPrivateTarget.Error())
+
+	// Redirects are recorded as failures rather than followed.
+	env = newWebhookEnv(t)
+	hook = env.register(t, redirect.URL)
+	resp = doJSON(t, http.MethodPost, env.server.URL+"/books",
+		models.Book{Title: "Redirected", Author: "Test Author", BookYear: 2024})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+	require.Equal(t, 1, env.deliver(t))
+
+	d = env.deliveries(t, hook.ID)[0]
+	assert.Equal(t, http.StatusFound, d.ResponseStatus)
+	assert.Equal(t, models.DeliveryPending, d.Status)
+}
+
+func TestWebhooks_API(t *testing.T) {
+	env := newWebhookEnv(t)
+	base := env.server.URL + "/webhooks"
+
+	for name, tc := range map[string]struct {
+		body map[string]any
+		want string
+	}{
+		"relative url":  {map[string]any{"url": "/hooks", "secret": "s"}, "url must be an absolute http or https URL"},
+		"other scheme":  {map[string]any{"url": "ftp://example.com", "secret": "s"}, "url must be an absolute http or https URL"},
+		"unknown event": {map[string]any{"url": "https://example.com", "secret": "s", "event_types": []string{"BookBurned"}}, `unknown event type "BookBurned"`},
+		"no secret":     {map[string]any{"url": "https://example.com"}, "secret is required"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := doJSON(t, http.MethodPost, base, tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			msg, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(msg), tc.want)
+		})
+	}
+
+	hook := env.register(t, "https://example.com/hooks", models.BookPurged)
+	resp := doJSON(t, http.MethodGet, base, nil)
+	raw, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "whsec_test")
+	var listed []models.Webhook
+	require.NoError(t, json.Unmarshal(raw, &listed))
+	require.Len(t, listed, 1)
+	assert.Equal(t, []models.BookEventType{models.BookPurged}, listed[0].EventTypes)
+
+	resp = doJSON(t, http.MethodGet, fmt.Sprintf("%s/%d/deliveries?limit=lots", base, hook.ID), nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Empty(t, env.deliveries(t, hook.ID))
+
+	resp = doJSON(t, http.MethodDelete, fmt.Sprintf("%s/%d", base, hook.ID), nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	for _, path := range []string{"", "/deliveries"} {
+		resp = doJSON(t, http.MethodGet, fmt.Sprintf("%s/%d%s", base, hook.ID, path), nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+	}
+	resp = doJSON(t, http.MethodPost, fmt.Sprintf("%s/%d:enable", base, hook.ID), nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhookSignature_Verify(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signedAt := time.Unix(1700000000, 0)
+	sig := webhook.Sign("secret", signedAt, body)
+	assert.Regexp(t, `^t=1700000000,v1=[0-9a-f]{64}$`, sig)
+
+	assert.NoError(t, webhook.Verify("secret", sig, body, time.Minute, signedAt.Add(time.Second)))
+	assert.ErrorIs(t, webhook.Verify("other", sig, body, 0, signedAt), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("secret", sig, []byte(`{"id":2}`), 0, signedAt), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("secret", sig, body, time.Minute, signedAt.Add(time.Hour)), webhook.ErrInvalidSignature,
+		"stale signatures are replays")
+	assert.ErrorIs(t, webhook.Verify("secret", "v1=abc", body, 0, signedAt), webhook.ErrInvalidSignature)
+}